@@ -1,7 +1,14 @@
 package zeroordered
 
 import (
+	"fmt"
 	"math"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/vshulcz/edu_optimization_methods/pkg"
 )
 
 // PassiveSearch реализует метод пассивного поиска (равномерного перебора)
@@ -9,16 +16,18 @@ import (
 //
 // Алгоритм делит отрезок [a, b] на k равных частей, где k ≥ (b - a)/eps,
 // и вычисляет значения функции в точках x_i = a + i*(b - a)/k для i = 0..k.
-// Возвращается точка xmin, в которой достигается минимальное значение fmin,
-// а также количество вызовов функции f (iters).
+// Возвращается точка xmin, в которой достигается минимальное значение fmin.
 //
 // Особенности:
 // - Простой и надёжный, но неэффективный при высокой точности (большое число итераций).
 // - Не использует информацию о поведении функции, только значения в равномерных точках.
 // - Погрешность не превышает (b - a)/k ≤ eps.
-func PassiveSearch(f func(x float64) float64, a, b, eps float64) (xmin, fmin float64, iters int) {
+//
+// Возвращает pkg.Result1D: xmin, fmin, отрезок [xmin - h, xmin + h] ∩ [a, b] (h — шаг сетки),
+// число точек сетки (Iters) и вызовов f (FEvals).
+func PassiveSearch(f func(x float64) float64, a, b, eps float64) (res pkg.Result1D) {
 	phiF := func(x_ float64) float64 {
-		iters++
+		res.FEvals++
 		return f(x_)
 	}
 
@@ -36,7 +45,110 @@ func PassiveSearch(f func(x float64) float64, a, b, eps float64) (xmin, fmin flo
 		}
 	}
 
-	return minX, minVal, iters
+	evals := res.FEvals
+	res = passiveResult(minX, minVal, a, b, k)
+	res.FEvals = evals
+	return res
+}
+
+// PassiveSearchParallel — параллельный вариант PassiveSearch для дорогих функций f.
+//
+// Точки равномерной сетки x_i = a + i*(b - a)/k, i = 0..k, те же, что и в PassiveSearch;
+// значения f в них вычисляются пулом из workers горутин (при workers ≤ 0 — по числу
+// доступных процессоров). Минимум выбирается после вычисления всех значений
+// последовательным просмотром сетки, поэтому для детерминированной f результат
+// в точности совпадает с PassiveSearch, включая выбор среди равных значений.
+//
+// Особенности:
+// - f вызывается одновременно из нескольких горутин и должна быть к этому готова.
+// - Требует O(k) памяти под значения функции.
+//
+// Возвращает pkg.Result1D, как и PassiveSearch.
+func PassiveSearchParallel(f func(x float64) float64, a, b, eps float64, workers int) pkg.Result1D {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	xs := passiveGrid(a, b, eps)
+	fs := make([]float64, len(xs))
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for range min(workers, len(xs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= len(xs) {
+					return
+				}
+				fs[i] = f(xs[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	xmin, fmin := passiveArgmin(xs, fs)
+	res := passiveResult(xmin, fmin, a, b, len(xs)-1)
+	res.FEvals = len(xs)
+	return res
+}
+
+// PassiveSearchBatch — вариант PassiveSearch для пакетного вычисления функции:
+// batch получает все точки сетки x_0..x_k сразу и возвращает значения в том же порядке.
+// Это удобно, если f считается внешней системой (симуляцией, векторизованным кодом),
+// которой выгодно передавать точки пачкой.
+//
+// Результат совпадает с PassiveSearch при batch(xs)[i] = f(xs[i]);
+// FEvals — число точек, переданных в batch.
+// Если batch вернул не столько значений, сколько получил точек, возвращается ошибка ErrBatchSize.
+func PassiveSearchBatch(batch func(xs []float64) []float64, a, b, eps float64) (pkg.Result1D, error) {
+	xs := passiveGrid(a, b, eps)
+	fs := batch(xs)
+	if len(fs) != len(xs) {
+		return pkg.Result1D{}, fmt.Errorf("%w: got %d values for %d points", ErrBatchSize, len(fs), len(xs))
+	}
+
+	xmin, fmin := passiveArgmin(xs, fs)
+	res := passiveResult(xmin, fmin, a, b, len(xs)-1)
+	res.FEvals = len(xs)
+	return res, nil
+}
+
+// passiveGrid возвращает точки сетки метода пассивного поиска.
+func passiveGrid(a, b, eps float64) []float64 {
+	k := int(math.Ceil((b - a) / eps))
+	xs := make([]float64, k+1)
+	for i := range xs {
+		xs[i] = a + float64(i)*(b-a)/float64(k)
+	}
+	return xs
+}
+
+// passiveResult собирает результат метода пассивного поиска на сетке из k отрезков:
+// Iters — число узлов сетки k + 1, финальный отрезок — соседние с xmin узлы.
+// Число вызовов f (FEvals) заполняет вызывающий метод.
+func passiveResult(xmin, fmin, a, b float64, k int) pkg.Result1D {
+	h := (b - a) / float64(k)
+	return pkg.Result1D{
+		Xmin:   xmin,
+		Fmin:   fmin,
+		AFinal: max(xmin-h, a),
+		BFinal: min(xmin+h, b),
+		Iters:  k + 1,
+		Reason: pkg.StopInterval,
+	}
+}
+
+// passiveArgmin выбирает первую точку с наименьшим значением, как PassiveSearch.
+func passiveArgmin(xs, fs []float64) (xmin, fmin float64) {
+	xmin, fmin = xs[0], math.Inf(1)
+	for i, v := range fs {
+		if v < fmin {
+			xmin, fmin = xs[i], v
+		}
+	}
+	return
 }
 
 // DichotomySearch реализует метод дихотомии (двоичного поиска) для одномерной минимизации функции f
@@ -55,14 +167,15 @@ func PassiveSearch(f func(x float64) float64, a, b, eps float64) (xmin, fmin flo
 //   - Количество итераций логарифмически зависит от начальной длины отрезка и eps.
 //   - Алгоритм подходит для унимодальных функций (имеющих единственный минимум на [a, b]).
 //
-// Возвращает найденное значение xmin, значение функции в этой точке fmin,
-// а также границы финального локализующего отрезка и число вызовов f (iters).
-func DichotomySearch(f func(x float64) float64, a, b, eps, delta float64) (xmin, fmin, aFinal, bFinal float64, iters int) {
+// Возвращает pkg.Result1D: найденное значение xmin, значение функции в этой точке fmin,
+// границы финального локализующего отрезка, число итераций и вызовов f.
+func DichotomySearch(f func(x float64) float64, a, b, eps, delta float64) (res pkg.Result1D) {
 	phiF := func(x_ float64) float64 {
-		iters++
+		res.FEvals++
 		return f(x_)
 	}
 	for (b-a)/2.0 > eps {
+		res.Iters++
 		mid := (a + b) / 2.0
 		c := mid - delta/2.0
 		d := mid + delta/2.0
@@ -73,10 +186,10 @@ func DichotomySearch(f func(x float64) float64, a, b, eps, delta float64) (xmin,
 			a = c
 		}
 	}
-	xmin = (a + b) / 2.0
-	fmin = phiF(xmin)
-	aFinal = a
-	bFinal = b
+	res.Xmin = (a + b) / 2.0
+	res.Fmin = phiF(res.Xmin)
+	res.AFinal, res.BFinal = a, b
+	res.Reason = pkg.StopInterval
 	return
 }
 
@@ -100,10 +213,11 @@ func DichotomySearch(f func(x float64) float64, a, b, eps, delta float64) (xmin,
 // - Эффективен для унимодальных функций.
 // - Длина отрезка уменьшается на фиксированную долю (≈ 0.618) на каждом шаге.
 //
-// Возвращает точку минимума xmin, значение функции в ней fmin и общее число вызовов функции f (iters).
-func GoldenSectionSearch(f func(x float64) float64, a, b, eps float64) (xmin, fmin float64, iters int) {
+// Возвращает pkg.Result1D: точку минимума xmin, значение функции в ней fmin,
+// финальный отрезок, число итераций и общее число вызовов функции f.
+func GoldenSectionSearch(f func(x float64) float64, a, b, eps float64) (res pkg.Result1D) {
 	phiF := func(x_ float64) float64 {
-		iters++
+		res.FEvals++
 		return f(x_)
 	}
 	ad := (math.Sqrt(5) - 1) / 2
@@ -115,6 +229,7 @@ func GoldenSectionSearch(f func(x float64) float64, a, b, eps float64) (xmin, fm
 	fd := phiF(d)
 
 	for (b-a)/2.0 > eps {
+		res.Iters++
 		if fc <= fd {
 			b = d
 			d = c
@@ -126,12 +241,14 @@ func GoldenSectionSearch(f func(x float64) float64, a, b, eps float64) (xmin, fm
 			c = d
 			fc = fd
 			d = a + ad*(b-a)
-			fd = f(d)
+			fd = phiF(d)
 		}
 	}
 
-	xmin = (a + b) / 2.0
-	fmin = phiF(xmin)
+	res.Xmin = (a + b) / 2.0
+	res.Fmin = phiF(res.Xmin)
+	res.AFinal, res.BFinal = a, b
+	res.Reason = pkg.StopInterval
 	return
 }
 
@@ -157,10 +274,11 @@ func GoldenSectionSearch(f func(x float64) float64, a, b, eps float64) (xmin, fm
 // - Эффективен при заранее известном числе итераций, экономит вызовы f.
 // - Требует генерации последовательности Фибоначчи до достижения нужной длины.
 //
-// Возвращает точку минимума xmin, значение функции в этой точке fmin и количество вызовов функции f (iters).
-func FibonacciSearch(f func(x float64) float64, a, b, eps float64) (xmin, fmin float64, iters int) {
+// Возвращает pkg.Result1D: точку минимума xmin, значение функции в этой точке fmin,
+// финальный отрезок, число сокращений отрезка и количество вызовов функции f.
+func FibonacciSearch(f func(x float64) float64, a, b, eps float64) (res pkg.Result1D) {
 	phiF := func(x_ float64) float64 {
-		iters++
+		res.FEvals++
 		return f(x_)
 	}
 	fib := []float64{1, 1}
@@ -183,6 +301,7 @@ func FibonacciSearch(f func(x float64) float64, a, b, eps float64) (xmin, fmin f
 	f2 := phiF(x2)
 
 	for k := 1; k <= n-3; k++ {
+		res.Iters++
 		if f1 > f2 {
 			a_n = x1
 			x1 = x2
@@ -198,6 +317,7 @@ func FibonacciSearch(f func(x float64) float64, a, b, eps float64) (xmin, fmin f
 		}
 	}
 
+	res.Iters++
 	delta := eps / 10.0
 	x2 = x1 + delta
 	f2 = phiF(x2)
@@ -207,7 +327,525 @@ func FibonacciSearch(f func(x float64) float64, a, b, eps float64) (xmin, fmin f
 		b_n = x2
 	}
 
-	xmin = (a_n + b_n) / 2.0
-	fmin = phiF(xmin)
+	res.Xmin = (a_n + b_n) / 2.0
+	res.Fmin = phiF(res.Xmin)
+	res.AFinal, res.BFinal = a_n, b_n
+	res.Reason = pkg.StopInterval
 	return
 }
+
+// BrentSearch реализует комбинированный метод Брента для одномерной минимизации функции f
+// на отрезке [a, b] с заданной точностью eps.
+//
+// Метод сочетает надёжность золотого сечения со скоростью метода парабол:
+// на каждой итерации через три лучшие найденные точки (x, w, v) строится парабола,
+// и если её вершина u лежит внутри текущего отрезка и шаг меньше половины
+// предпредыдущего шага, то следующей точкой берётся u (параболический шаг).
+// Иначе выполняется шаг золотого сечения в большую из частей [a, x] или [x, b].
+//
+// Обозначения:
+// - x — точка с наименьшим найденным значением f;
+// - w — точка со вторым наименьшим значением f;
+// - v — точка с третьим наименьшим значением f (как правило, предыдущее значение w);
+// - u — точка, в которой вычисляется f на текущей итерации.
+//
+// Цикл продолжается, пока max(x - a, b - x) > 2·tol, где tol = eps/2 + √ε_маш·|x|.
+//
+// Особенности:
+//   - На гладких функциях вблизи минимума сходится сверхлинейно (порядок ≈ 1.324),
+//     обычно за 6–8 вычислений f вместо десятков у золотого сечения.
+//   - В худшем случае не медленнее золотого сечения более чем в константу раз.
+//   - Одно новое вычисление f на каждой итерации.
+//   - Минимум оценивается лучшей найденной точкой x, а не серединой отрезка.
+//
+// Возвращает pkg.Result1D: точку минимума xmin, значение функции в ней fmin,
+// границы финального локализующего отрезка, число итераций и вызовов f.
+func BrentSearch(f func(x float64) float64, a, b, eps float64) (res pkg.Result1D) {
+	phiF := func(x_ float64) float64 {
+		res.FEvals++
+		return f(x_)
+	}
+	ac := (3 - math.Sqrt(5)) / 2
+	sqrtEps := math.Sqrt(math.Nextafter(1, 2) - 1)
+
+	x := a + ac*(b-a)
+	w, v := x, x
+	fx := phiF(x)
+	fw, fv := fx, fx
+
+	// d — текущий шаг, e — шаг на предпредыдущей итерации
+	var d, e float64
+	for {
+		m := (a + b) / 2
+		tol := eps/2 + sqrtEps*math.Abs(x)
+		tol2 := 2 * tol
+		if math.Abs(x-m) <= tol2-(b-a)/2 {
+			break
+		}
+		res.Iters++
+
+		golden := true
+		if math.Abs(e) > tol {
+			// парабола через (x, fx), (w, fw), (v, fv)
+			r := (x - w) * (fx - fv)
+			q := (x - v) * (fx - fw)
+			p := (x-v)*q - (x-w)*r
+			q = 2 * (q - r)
+			if q > 0 {
+				p = -p
+			} else {
+				q = -q
+			}
+			if math.Abs(p) < math.Abs(q*e/2) && p > q*(a-x) && p < q*(b-x) {
+				e = d
+				d = p / q
+				u := x + d
+				// не вычисляем f слишком близко к границам
+				if u-a < tol2 || b-u < tol2 {
+					d = math.Copysign(tol, m-x)
+				}
+				golden = false
+			}
+		}
+		if golden {
+			if x < m {
+				e = b - x
+			} else {
+				e = a - x
+			}
+			d = ac * e
+		}
+
+		// не вычисляем f ближе чем на tol к x
+		u := x + d
+		if math.Abs(d) < tol {
+			u = x + math.Copysign(tol, d)
+		}
+		fu := phiF(u)
+
+		if fu <= fx {
+			if u < x {
+				b = x
+			} else {
+				a = x
+			}
+			v, fv = w, fw
+			w, fw = x, fx
+			x, fx = u, fu
+		} else {
+			if u < x {
+				a = u
+			} else {
+				b = u
+			}
+			if fu <= fw || w == x {
+				v, fv = w, fw
+				w, fw = u, fu
+			} else if fu <= fv || v == x || v == w {
+				v, fv = u, fu
+			}
+		}
+	}
+
+	res.Xmin, res.Fmin = x, fx
+	res.AFinal, res.BFinal = a, b
+	res.Reason = pkg.StopInterval
+	return
+}
+
+// ParabolicSearch реализует метод последовательной квадратичной интерполяции (метод парабол Пауэлла)
+// для одномерной минимизации функции f на отрезке [a, b] с заданной точностью eps.
+// Метод не использует производные — только значения функции.
+//
+// Алгоритм:
+//   - Начальная тройка точек: x1 = a, x2 = (a + b)/2, x3 = b.
+//   - Через точки (x1, f1), (x2, f2), (x3, f3) проводится парабола, и её вершина
+//     u = x2 - ½·[(x2-x1)²(f2-f3) - (x2-x3)²(f2-f1)] / [(x2-x1)(f2-f3) - (x2-x3)(f2-f1)]
+//     становится следующей точкой.
+//   - Тройка сужается так же, как в методах дихотомии и золотого сечения:
+//     из x1, x3 отбрасывается та точка, со стороны которой значение в u или x2 больше.
+//   - Цикл продолжается, пока длина отрезка [x1, x3] не станет ≤ 2 * eps. Близость
+//     последовательных вершин критерием остановы не служит: вершины могут сгущаться у одного
+//     конца отрезка, не гарантируя точности eps.
+//
+// Защитные меры:
+//   - Если точки почти коллинеарны (знаменатель ≈ 0) или парабола направлена ветвями вниз
+//     (тройка невыпукла), вершина не имеет смысла, и вместо неё делается шаг
+//     золотого сечения в большую из частей [x1, x2] или [x2, x3].
+//   - Если вершина выходит за пределы (x1, x3), также делается шаг золотого сечения.
+//   - Если за две итерации отрезок не сократился хотя бы вдвое (вершины подходят к минимуму
+//     с одной стороны), делается шаг золотого сечения.
+//   - Новая точка не берётся ближе eps/2 к x2, чтобы не повторять вычисления.
+//
+// Особенности:
+//   - На гладких функциях вблизи минимума сходится сверхлинейно (порядок ≈ 1.324).
+//   - Для квадратичной функции находит минимум за одну интерполяцию.
+//   - Одно новое вычисление f на итерацию, как у методов золотого сечения и Фибоначчи.
+//
+// Возвращает pkg.Result1D: точку минимума xmin (лучшую из найденных), значение функции в ней fmin,
+// финальный отрезок [x1, x3], число итераций и общее число вызовов функции f.
+func ParabolicSearch(f func(x float64) float64, a, b, eps float64) (res pkg.Result1D) {
+	phiF := func(x_ float64) float64 {
+		res.FEvals++
+		return f(x_)
+	}
+	ac := (3 - math.Sqrt(5)) / 2
+
+	x1, x2, x3 := a, (a+b)/2, b
+	f1, f2, f3 := phiF(x1), phiF(x2), phiF(x3)
+
+	// длины отрезка на двух предыдущих итерациях
+	w1, w2 := math.Inf(1), math.Inf(1)
+	for (x3-x1)/2 > eps {
+		res.Iters++
+		num := (x2-x1)*(x2-x1)*(f2-f3) - (x2-x3)*(x2-x3)*(f2-f1)
+		den := (x2-x1)*(f2-f3) - (x2-x3)*(f2-f1)
+
+		// вторая разделённая разность > 0 ⇔ den < 0: парабола выпукла
+		// если за две итерации отрезок не сократился вдвое, парабола
+		// подходит к минимуму с одной стороны, и нужен шаг золотого сечения
+		parabolic := den < 0 && x3-x1 <= w2/2
+		w2, w1 = w1, x3-x1
+		var u float64
+		if parabolic {
+			u = x2 - num/(2*den)
+			parabolic = u > x1 && u < x3
+		}
+		if !parabolic {
+			if x2-x1 > x3-x2 {
+				u = x2 - ac*(x2-x1)
+			} else {
+				u = x2 + ac*(x3-x2)
+			}
+		}
+
+		if math.Abs(u-x2) < eps/2 {
+			// шаг в сторону u, если там есть место, иначе — в противоположную
+			if u < x2 && x2-x1 > eps || x3-x2 <= eps {
+				u = x2 - eps/2
+			} else {
+				u = x2 + eps/2
+			}
+		}
+		fu := phiF(u)
+
+		if u < x2 {
+			if fu < f2 {
+				x3, f3 = x2, f2
+				x2, f2 = u, fu
+			} else {
+				x1, f1 = u, fu
+			}
+		} else {
+			if fu < f2 {
+				x1, f1 = x2, f2
+				x2, f2 = u, fu
+			} else {
+				x3, f3 = u, fu
+			}
+		}
+	}
+
+	res.Xmin, res.Fmin = x2, f2
+	res.AFinal, res.BFinal = x1, x3
+	res.Reason = pkg.StopInterval
+	return
+}
+
+// PiyavskiiSearch реализует метод ломаных Пиявского–Шуберта для глобальной минимизации
+// (возможно многоэкстремальной) функции f на отрезке [a, b], удовлетворяющей условию Липшица
+// |f(x) - f(y)| ≤ L·|x - y|, с заданной точностью eps по значению функции.
+//
+// Для каждой пары соседних точек испытаний x_i < x_{i+1} строится миноранта
+// max(f_i - L(x - x_i), f_{i+1} - L(x_{i+1} - x)) ("пила"). Её минимум на [x_i, x_{i+1}]:
+// x* = (x_i + x_{i+1})/2 + (f_i - f_{i+1})/(2L),
+// R_i = (f_i + f_{i+1})/2 - L(x_{i+1} - x_i)/2.
+//
+// Алгоритм:
+//   - Начальные испытания: a, (a + b)/2, b.
+//   - На каждой итерации выбирается отрезок с наименьшей оценкой R_i, и в точке x* этого
+//     отрезка вычисляется f. Наименьшая из R_i — нижняя граница глобального минимума.
+//   - Цикл продолжается, пока разность между лучшим найденным значением и нижней границей
+//     не станет ≤ eps.
+//
+// Если L ≤ 0, константа Липшица оценивается адаптивно по испытаниям:
+// L = 2 · max |f_{i+1} - f_i| / (x_{i+1} - x_i) и пересчитывается на каждой итерации.
+// В этом случае нижняя граница гарантирована лишь постольку, поскольку оценка L
+// не меньше истинной константы.
+//
+// Особенности:
+//   - В отличие от остальных методов пакета не требует унимодальности f.
+//   - Испытания сгущаются вблизи глобального минимума, а не распределяются равномерно,
+//     как в методе пассивного поиска.
+//   - При завышенной L метод остаётся корректным, но тратит больше вычислений f.
+//
+// Возвращает pkg.Result1D (точку глобального минимума xmin, значение функции в ней fmin,
+// соседние с xmin точки испытаний как финальный отрезок, число итераций и вызовов f)
+// и нижнюю границу глобального минимума lowerBound.
+func PiyavskiiSearch(f func(x float64) float64, a, b, L, eps float64) (res pkg.Result1D, lowerBound float64) {
+	phiF := func(x_ float64) float64 {
+		res.FEvals++
+		return f(x_)
+	}
+
+	xs := []float64{a, (a + b) / 2, b}
+	fs := []float64{phiF(xs[0]), phiF(xs[1]), phiF(xs[2])}
+
+	xmin, fmin := xs[0], fs[0]
+	for i := range xs {
+		if fs[i] < fmin {
+			xmin, fmin = xs[i], fs[i]
+		}
+	}
+
+	for {
+		l := L
+		if l <= 0 {
+			var slope float64
+			for i := 0; i+1 < len(xs); i++ {
+				slope = max(slope, math.Abs(fs[i+1]-fs[i])/(xs[i+1]-xs[i]))
+			}
+			l = max(2*slope, 1e-8)
+		}
+
+		best := 0
+		lowerBound = math.Inf(1)
+		for i := 0; i+1 < len(xs); i++ {
+			r := (fs[i]+fs[i+1])/2 - l*(xs[i+1]-xs[i])/2
+			if r < lowerBound {
+				lowerBound = r
+				best = i
+			}
+		}
+		if fmin-lowerBound <= eps {
+			res.Reason = pkg.StopGap
+			break
+		}
+
+		x := (xs[best]+xs[best+1])/2 + (fs[best]-fs[best+1])/(2*l)
+		if x <= xs[best] || x >= xs[best+1] {
+			res.Reason = pkg.StopDegenerate
+			break
+		}
+		res.Iters++
+		fx := phiF(x)
+		if fx < fmin {
+			xmin, fmin = x, fx
+		}
+
+		xs = append(xs, 0)
+		fs = append(fs, 0)
+		copy(xs[best+2:], xs[best+1:])
+		copy(fs[best+2:], fs[best+1:])
+		xs[best+1], fs[best+1] = x, fx
+	}
+
+	res.Xmin, res.Fmin = xmin, fmin
+	i := sort.SearchFloat64s(xs, xmin)
+	res.AFinal, res.BFinal = xs[max(i-1, 0)], xs[min(i+1, len(xs)-1)]
+	return
+}
+
+// FibonacciSearchInt реализует метод Фибоначчи для функции f целочисленного аргумента,
+// унимодальной на отрезке [lo, hi] (например, размер пакета или число потоков).
+//
+// Отрезок дополняется справа до длины F(m) ≥ hi - lo + 2, где F(m) — число Фибоначчи,
+// а значения в добавленных точках (x > hi) считаются равными +∞ и не вычисляются.
+// Пусть a = lo - 1, b = a + F(m). На каждой итерации сравниваются точки
+// x1 = a + F(m-2) и x2 = a + F(m-1) — обе целые, — и отрезок сокращается до длины F(m-1):
+// если f(x1) ≤ f(x2), то b = x2, иначе a = x1. Одна из точек сохраняется,
+// поэтому на итерации вычисляется одно новое значение f.
+// Когда b - a = 2, единственная внутренняя точка a + 1 и есть минимум.
+//
+// Особенности:
+// - Находит точный целочисленный минимизатор за m - 2 сравнений.
+// - Значения f запоминаются, ни одна точка не вычисляется дважды.
+//
+// Возвращает pkg.Result1D с целым xmin, финальным отрезком [a, a + 2] ∩ [lo, hi]
+// и числом сравнений (Iters).
+func FibonacciSearchInt(f func(x int) float64, lo, hi int) (res pkg.Result1D) {
+	phiF := memoizedInt(f, hi, &res.FEvals)
+
+	fib := []int{1, 1, 2}
+	for fib[len(fib)-1] < hi-lo+2 {
+		fib = append(fib, fib[len(fib)-1]+fib[len(fib)-2])
+	}
+
+	a := lo - 1
+	for k := len(fib) - 1; k > 2; k-- {
+		res.Iters++
+		x1 := a + fib[k-2]
+		x2 := a + fib[k-1]
+		if phiF(x1) > phiF(x2) {
+			a = x1
+		}
+	}
+
+	xmin := a + 1
+	res.Xmin, res.Fmin = float64(xmin), phiF(xmin)
+	res.AFinal, res.BFinal = float64(max(a, lo)), float64(min(a+2, hi))
+	res.Reason = pkg.StopInterval
+	return
+}
+
+// GoldenSectionSearchInt реализует метод золотого сечения для функции f целочисленного аргумента,
+// унимодальной на отрезке [lo, hi].
+//
+// Точки деления c = lo + round((3 - √5)/2 * (hi - lo)) и d = lo + round((√5 - 1)/2 * (hi - lo))
+// округляются до целых. Как и в непрерывном случае, после сравнения f(c) и f(d) одна из точек
+// остаётся внутренней точкой нового отрезка, и вычисляется только одна новая точка
+// (при совпадении после округления она сдвигается на единицу).
+// Когда в отрезке остаётся не более трёх точек, они перебираются.
+//
+// Особенности:
+// - Находит точный целочисленный минимизатор.
+// - Значения f запоминаются, ни одна точка не вычисляется дважды.
+//
+// Возвращает pkg.Result1D с целым xmin, финальным отрезком (не более трёх точек)
+// и числом сравнений (Iters).
+func GoldenSectionSearchInt(f func(x int) float64, lo, hi int) (res pkg.Result1D) {
+	phiF := memoizedInt(f, hi, &res.FEvals)
+	ad := (math.Sqrt(5) - 1) / 2
+	ac := (3 - math.Sqrt(5)) / 2
+	round := func(v float64) int { return int(math.Round(v)) }
+
+	c := lo + round(ac*float64(hi-lo))
+	d := lo + round(ad*float64(hi-lo))
+	if d <= c {
+		d = c + 1
+	}
+	for hi-lo > 2 {
+		res.Iters++
+		if phiF(c) <= phiF(d) {
+			hi = d
+			d = c
+			c = lo + round(ac*float64(hi-lo))
+			if c >= d {
+				c = d - 1
+			}
+		} else {
+			lo = c
+			c = d
+			d = lo + round(ad*float64(hi-lo))
+			if d <= c {
+				d = c + 1
+			}
+		}
+	}
+
+	xmin, fmin := lo, phiF(lo)
+	for x := lo + 1; x <= hi; x++ {
+		if fx := phiF(x); fx < fmin {
+			xmin, fmin = x, fx
+		}
+	}
+	res.Xmin, res.Fmin = float64(xmin), fmin
+	res.AFinal, res.BFinal = float64(lo), float64(hi)
+	res.Reason = pkg.StopInterval
+	return
+}
+
+// memoizedInt запоминает значения f и считает только новые вычисления;
+// точки правее hi получают значение +∞ без вызова f.
+func memoizedInt(f func(x int) float64, hi int, iters *int) func(x int) float64 {
+	cache := make(map[int]float64)
+	return func(x int) float64 {
+		if x > hi {
+			return math.Inf(1)
+		}
+		if v, ok := cache[x]; ok {
+			return v
+		}
+		*iters++
+		v := f(x)
+		cache[x] = v
+		return v
+	}
+}
+
+// NoisyDichotomySearch — вариант метода дихотомии для функций, значения которых измеряются
+// с шумом (время работы программы, метод Монте-Карло).
+//
+// Вместо одного сравнения f(c) ≤ f(d) в точках c и d делается по opts.MinSamples замеров,
+// и решение об отбрасывании части отрезка принимается по критерию Уэлча: разность средних
+// делится на её стандартную ошибку, и достоверность решения оценивается как Φ(|m_c - m_d| / σ).
+// Пока достоверность ниже opts.Confidence, в обеих точках делаются дополнительные замеры
+// (не больше opts.MaxSamples в точке и opts.Budget всего).
+//
+// Цикл, как и в DichotomySearch, продолжается, пока (b - a)/2 > eps,
+// и досрочно прекращается при исчерпании бюджета.
+//
+// Особенности:
+//   - При детерминированной f каждое сравнение стоит 2·MinSamples вызовов, а решения
+//     совпадают с DichotomySearch.
+//   - Если решение пришлось принять без нужной достоверности (исчерпан MaxSamples или бюджет),
+//     это отражается в итоговой достоверности.
+//
+// Возвращает pkg.Result1D (середину финального отрезка xmin, среднее значение f в ней fmin,
+// границы финального отрезка, число сравнений и общее число вызовов f) и достоверность того,
+// что финальный отрезок содержит минимум (произведение достоверностей всех решений).
+func NoisyDichotomySearch(
+	f func(x float64) float64,
+	a, b, eps, delta float64,
+	opts NoiseOptions,
+) (res pkg.Result1D, confidence float64) {
+	s := newNoisySampler(f, opts)
+
+	confidence = 1
+	for (b-a)/2.0 > eps && !s.exhausted() {
+		res.Iters++
+		mid := (a + b) / 2.0
+		c := s.point(mid - delta/2.0)
+		d := s.point(mid + delta/2.0)
+
+		cLess, conf := s.less(c, d)
+		confidence *= conf
+		if cLess {
+			b = d.x
+		} else {
+			a = c.x
+		}
+	}
+
+	return s.result(res, a, b, eps), confidence
+}
+
+// NoisyGoldenSectionSearch — вариант метода золотого сечения для функций, значения которых
+// измеряются с шумом. Сравнение точек c и d выполняется так же, как в NoisyDichotomySearch:
+// по нескольким замерам и критерию Уэлча с требуемой достоверностью opts.Confidence.
+//
+// Сохраняемая на следующей итерации точка сохраняет и все свои замеры,
+// поэтому оценки в ней со временем уточняются.
+//
+// Возвращает pkg.Result1D и достоверность того, что финальный отрезок содержит минимум,
+// как и NoisyDichotomySearch.
+func NoisyGoldenSectionSearch(
+	f func(x float64) float64,
+	a, b, eps float64,
+	opts NoiseOptions,
+) (res pkg.Result1D, confidence float64) {
+	s := newNoisySampler(f, opts)
+	ad := (math.Sqrt(5) - 1) / 2
+	ac := (3 - math.Sqrt(5)) / 2
+
+	c := s.point(a + ac*(b-a))
+	d := s.point(a + ad*(b-a))
+
+	confidence = 1
+	for (b-a)/2.0 > eps && !s.exhausted() {
+		res.Iters++
+		cLess, conf := s.less(c, d)
+		confidence *= conf
+		if cLess {
+			b = d.x
+			d = c
+			c = s.point(a + ac*(b-a))
+		} else {
+			a = c.x
+			c = d
+			d = s.point(a + ad*(b-a))
+		}
+	}
+
+	return s.result(res, a, b, eps), confidence
+}
@@ -1,8 +1,12 @@
 package zeroordered
 
 import (
+	"errors"
 	"math"
+	"math/rand"
 	"testing"
+
+	"github.com/vshulcz/edu_optimization_methods/pkg"
 )
 
 func BenchmarkFibonacciSearch(b *testing.B) {
@@ -27,6 +31,17 @@ func BenchmarkGoldenSectionSearch(b *testing.B) {
 	}
 }
 
+func BenchmarkBrentSearch(b *testing.B) {
+	testFunc := func(x float64) float64 {
+		return math.Pow(x-2, 2)
+	}
+	a, c, eps := 0.0, 4.0, 1e-5
+
+	for i := 0; i < b.N; i++ {
+		BrentSearch(testFunc, a, c, eps)
+	}
+}
+
 func TestPassiveSearch(t *testing.T) {
 	a := 0.5
 	b := 3.5
@@ -35,18 +50,61 @@ func TestPassiveSearch(t *testing.T) {
 		return x + 2/x
 	}
 
-	xmin, fmin, iterations := PassiveSearch(f, a, b, eps)
+	res := PassiveSearch(f, a, b, eps)
+
+	if res.Xmin != 1.5 {
+		t.Errorf("xmin = %v, expected 1.5", res.Xmin)
+	}
 
-	if xmin != 1.5 {
-		t.Errorf("xmin = %v, expected 1.5", xmin)
+	if res.Fmin-2.83 > eps {
+		t.Errorf("fmin = %v, expected 2.83", res.Fmin)
 	}
 
-	if fmin-2.83 > eps {
-		t.Errorf("fmin = %v, expected 2.83", fmin)
+	if res.AFinal != 1 || res.BFinal != 2 {
+		t.Errorf("[aFinal, bFinal] = [%v, %v], expected [1, 2]", res.AFinal, res.BFinal)
 	}
 
-	if iterations != 7 {
-		t.Errorf("iterations = %v, expected 7", iterations)
+	if res.FEvals != 7 || res.Iters != 7 {
+		t.Errorf("evals = %v, iters = %v, expected 7 grid points", res.FEvals, res.Iters)
+	}
+}
+
+func TestPassiveSearchParallel(t *testing.T) {
+	a := 0.5
+	b := 3.5
+	eps := 1e-3
+	f := func(x float64) float64 {
+		return x + 2/x
+	}
+	// несколько равных минимумов: должен выбираться первый, как в PassiveSearch
+	g := func(x float64) float64 {
+		return math.Round(math.Cos(8 * x))
+	}
+	batch := func(f func(float64) float64) func([]float64) []float64 {
+		return func(xs []float64) []float64 {
+			fs := make([]float64, len(xs))
+			for i, x := range xs {
+				fs[i] = f(x)
+			}
+			return fs
+		}
+	}
+
+	for _, fn := range []func(float64) float64{f, g} {
+		want := PassiveSearch(fn, a, b, eps)
+		for _, workers := range []int{0, 1, 3, 16} {
+			if got := PassiveSearchParallel(fn, a, b, eps, workers); got != want {
+				t.Errorf("workers = %d: got %+v, expected %+v", workers, got, want)
+			}
+		}
+		if got, err := PassiveSearchBatch(batch(fn), a, b, eps); err != nil || got != want {
+			t.Errorf("batch: got %+v, %v, expected %+v", got, err, want)
+		}
+	}
+
+	short := func(xs []float64) []float64 { return make([]float64, len(xs)-1) }
+	if _, err := PassiveSearchBatch(short, a, b, eps); !errors.Is(err, ErrBatchSize) {
+		t.Errorf("batch with missing values: err = %v, expected ErrBatchSize", err)
 	}
 }
 
@@ -58,21 +116,24 @@ func TestDichotomySearch(t *testing.T) {
 	f := func(x float64) float64 {
 		return x + 2/x
 	}
-	xmin, fmin, aFinal, bFinal, iterations := DichotomySearch(f, a, b, eps, delta)
-	if xmin-1.638 > eps {
-		t.Errorf("xmin = %v, expected 1.638", xmin)
+	res := DichotomySearch(f, a, b, eps, delta)
+	if res.Xmin-1.638 > eps {
+		t.Errorf("xmin = %v, expected 1.638", res.Xmin)
+	}
+	if res.Fmin-2.86 > eps {
+		t.Errorf("fmin = %v, expected 2.86", res.Fmin)
 	}
-	if fmin-2.86 > eps {
-		t.Errorf("fmin = %v, expected 2.86", fmin)
+	if res.AFinal-1.225 > eps {
+		t.Errorf("aFinal = %v, expected 1.225", res.AFinal)
 	}
-	if aFinal-1.225 > eps {
-		t.Errorf("aFinal = %v, expected 1.225", aFinal)
+	if res.BFinal-2.05 > eps {
+		t.Errorf("bFinal = %v, expected 2.05", res.BFinal)
 	}
-	if bFinal-2.05 > eps {
-		t.Errorf("bFinal = %v, expected 2.05", bFinal)
+	if res.Iters != 2 {
+		t.Errorf("iters = %v, expected 2", res.Iters)
 	}
-	if iterations != 5 {
-		t.Errorf("iterations = %v, expected 4", iterations)
+	if res.FEvals != 5 {
+		t.Errorf("evals = %v, expected 5", res.FEvals)
 	}
 }
 
@@ -83,15 +144,18 @@ func TestGoldenSectionSearch(t *testing.T) {
 	f := func(x float64) float64 {
 		return x + 2/x
 	}
-	xmin, fmin, i := GoldenSectionSearch(f, a, b, eps)
-	if xmin-1.562 > eps {
-		t.Errorf("xmin = %v, expected 1.562", xmin)
+	res := GoldenSectionSearch(f, a, b, eps)
+	if res.Xmin-1.562 > eps {
+		t.Errorf("xmin = %v, expected 1.562", res.Xmin)
 	}
-	if fmin-2.84 > eps {
-		t.Errorf("fmin = %v, expected 2.84", fmin)
+	if res.Fmin-2.84 > eps {
+		t.Errorf("fmin = %v, expected 2.84", res.Fmin)
 	}
-	if i != 5 {
-		t.Errorf("i = %v, expected 5", i)
+	if res.AFinal > res.Xmin || res.BFinal < res.Xmin || res.BFinal-res.AFinal > 2*eps {
+		t.Errorf("[aFinal, bFinal] = [%v, %v], expected interval around %v", res.AFinal, res.BFinal, res.Xmin)
+	}
+	if res.FEvals != 6 {
+		t.Errorf("evals = %v, expected 6", res.FEvals)
 	}
 }
 
@@ -102,14 +166,245 @@ func TestFibonacciSearch(t *testing.T) {
 	f := func(x float64) float64 {
 		return x + 2/x
 	}
-	xmin, fmin, i := FibonacciSearch(f, a, b, eps)
-	if xmin-1.25 > eps {
-		t.Errorf("xmin = %v, expected 1.25", xmin)
+	res := FibonacciSearch(f, a, b, eps)
+	if res.Xmin-1.25 > eps {
+		t.Errorf("xmin = %v, expected 1.25", res.Xmin)
+	}
+	if res.Fmin-2.85 > eps {
+		t.Errorf("fmin = %v, expected 2.85", res.Fmin)
+	}
+	if res.AFinal > res.Xmin || res.BFinal < res.Xmin || res.BFinal-res.AFinal > 2*eps {
+		t.Errorf("[aFinal, bFinal] = [%v, %v], expected interval around %v", res.AFinal, res.BFinal, res.Xmin)
+	}
+	if res.FEvals != 6 {
+		t.Errorf("evals = %v, expected 6", res.FEvals)
+	}
+}
+
+func TestBrentSearch(t *testing.T) {
+	a := 0.5
+	b := 3.5
+	eps := 1e-5
+	f := func(x float64) float64 {
+		return x + 2/x
+	}
+	res := BrentSearch(f, a, b, eps)
+	if math.Abs(res.Xmin-math.Sqrt2) > eps {
+		t.Errorf("xmin = %v, expected %v", res.Xmin, math.Sqrt2)
+	}
+	if math.Abs(res.Fmin-2*math.Sqrt2) > eps {
+		t.Errorf("fmin = %v, expected %v", res.Fmin, 2*math.Sqrt2)
+	}
+	if res.AFinal > res.Xmin || res.BFinal < res.Xmin || res.BFinal-res.AFinal > 4*eps {
+		t.Errorf("[aFinal, bFinal] = [%v, %v], expected short interval around %v", res.AFinal, res.BFinal, res.Xmin)
+	}
+	if res.FEvals != 12 {
+		t.Errorf("evals = %v, expected 12", res.FEvals)
+	}
+}
+
+func TestParabolicSearch(t *testing.T) {
+	a := 0.5
+	b := 3.5
+	eps := 0.5
+	f := func(x float64) float64 {
+		return x + 2/x
+	}
+	res := ParabolicSearch(f, a, b, eps)
+	if res.Xmin-1.5 > eps {
+		t.Errorf("xmin = %v, expected 1.5", res.Xmin)
+	}
+	if res.Fmin-2.83 > eps {
+		t.Errorf("fmin = %v, expected 2.83", res.Fmin)
+	}
+	if res.FEvals != 7 {
+		t.Errorf("evals = %v, expected 7", res.FEvals)
+	}
+
+	// квадратичная функция: минимум находится первой же параболой
+	g := func(x float64) float64 {
+		return (x - 2) * (x - 2)
+	}
+	res = ParabolicSearch(g, 0, 5, 1e-5)
+	if math.Abs(res.Xmin-2) > 1e-12 {
+		t.Errorf("xmin = %v, expected 2", res.Xmin)
+	}
+	if res.Fmin > 1e-24 {
+		t.Errorf("fmin = %v, expected 0", res.Fmin)
+	}
+	if res.FEvals != 6 {
+		t.Errorf("evals = %v, expected 6", res.FEvals)
 	}
-	if fmin-2.85 > eps {
-		t.Errorf("fmin = %v, expected 2.85", fmin)
+}
+
+func TestPiyavskiiSearch(t *testing.T) {
+	// многоэкстремальная функция: три локальных минимума на [2.7, 7.5],
+	// глобальный — в x ≈ 5.145735, f ≈ -1.899599
+	f := func(x float64) float64 {
+		return math.Sin(x) + math.Sin(10*x/3)
+	}
+	a, b, eps := 2.7, 7.5, 1e-4
+	wantX, wantF := 5.145735, -1.899599
+
+	for _, L := range []float64{4.3, 0} {
+		res, lowerBound := PiyavskiiSearch(f, a, b, L, eps)
+		if math.Abs(res.Xmin-wantX) > 1e-3 {
+			t.Errorf("L = %v: xmin = %v, expected %v", L, res.Xmin, wantX)
+		}
+		if math.Abs(res.Fmin-wantF) > eps {
+			t.Errorf("L = %v: fmin = %v, expected %v", L, res.Fmin, wantF)
+		}
+		if lowerBound > wantF || res.Fmin-lowerBound > eps {
+			t.Errorf("L = %v: lowerBound = %v, expected in [%v, %v]", L, lowerBound, res.Fmin-eps, wantF)
+		}
+		if res.AFinal >= res.Xmin || res.BFinal <= res.Xmin {
+			t.Errorf("L = %v: [aFinal, bFinal] = [%v, %v] does not contain %v", L, res.AFinal, res.BFinal, res.Xmin)
+		}
+		if res.Reason != pkg.StopGap {
+			t.Errorf("L = %v: reason = %v, expected %v", L, res.Reason, pkg.StopGap)
+		}
+		if res.FEvals >= 1000 {
+			t.Errorf("L = %v: evals = %v, expected < 1000", L, res.FEvals)
+		}
+	}
+}
+
+func TestIntSearch(t *testing.T) {
+	methods := map[string]func(f func(int) float64, lo, hi int) pkg.Result1D{
+		"FibonacciSearchInt":     FibonacciSearchInt,
+		"GoldenSectionSearchInt": GoldenSectionSearchInt,
+	}
+	ranges := [][2]int{{0, 0}, {0, 1}, {0, 2}, {3, 7}, {0, 100}, {-50, 1000}, {37, 37}, {38, 500}}
+	for name, search := range methods {
+		for _, r := range ranges {
+			for _, m := range []int{-60, 0, 1, 37, 99, 100, 700} {
+				seen := make(map[int]bool)
+				f := func(x int) float64 {
+					if seen[x] {
+						t.Errorf("%s [%d, %d], m = %d: f(%d) evaluated twice", name, r[0], r[1], m, x)
+					}
+					if x < r[0] || x > r[1] {
+						t.Errorf("%s [%d, %d], m = %d: f(%d) evaluated outside the interval", name, r[0], r[1], m, x)
+					}
+					seen[x] = true
+					return math.Abs(float64(x-m)) + 0.01*float64(x)
+				}
+				want := min(max(m, r[0]), r[1])
+				res := search(f, r[0], r[1])
+				if res.Xmin != float64(want) {
+					t.Errorf("%s [%d, %d], m = %d: xmin = %v, expected %d", name, r[0], r[1], m, res.Xmin, want)
+				}
+				if res.Fmin != math.Abs(float64(want-m))+0.01*float64(want) {
+					t.Errorf("%s [%d, %d], m = %d: fmin = %v does not match f(xmin)", name, r[0], r[1], m, res.Fmin)
+				}
+				if res.FEvals != len(seen) {
+					t.Errorf("%s [%d, %d], m = %d: evals = %d, expected %d", name, r[0], r[1], m, res.FEvals, len(seen))
+				}
+				if res.AFinal > res.Xmin || res.BFinal < res.Xmin || res.AFinal < float64(r[0]) || res.BFinal > float64(r[1]) || res.BFinal-res.AFinal > 2 {
+					t.Errorf("%s [%d, %d], m = %d: [aFinal, bFinal] = [%v, %v], expected a bracket of %v inside the interval", name, r[0], r[1], m, res.AFinal, res.BFinal, res.Xmin)
+				}
+			}
+		}
+	}
+
+	res := FibonacciSearchInt(func(x int) float64 { return float64((x - 300) * (x - 300)) }, 1, 1000)
+	if res.FEvals != 15 {
+		t.Errorf("FibonacciSearchInt: evals = %d, expected 15", res.FEvals)
 	}
-	if i != 6 {
-		t.Errorf("i = %v, expected 6", i)
+}
+
+func TestNoisySearch(t *testing.T) {
+	// детерминированная функция: решения совпадают с обычными методами
+	f := func(x float64) float64 {
+		return x + 2/x
+	}
+	want := DichotomySearch(f, 0.5, 3.5, 0.01, 0.001)
+	res, confidence := NoisyDichotomySearch(f, 0.5, 3.5, 0.01, 0.001, NoiseOptions{})
+	if res.Xmin != want.Xmin || res.AFinal != want.AFinal || res.BFinal != want.BFinal || res.Iters != want.Iters {
+		t.Errorf("NoisyDichotomySearch: got %+v, expected %+v", res, want)
+	}
+	if confidence != 1 {
+		t.Errorf("NoisyDichotomySearch: confidence = %v, expected 1", confidence)
+	}
+	if res.FEvals != 85 {
+		t.Errorf("NoisyDichotomySearch: evals = %v, expected 85", res.FEvals)
+	}
+
+	// зашумлённая функция: минимум x = 2 локализуется с заявленной достоверностью
+	r := rand.New(rand.NewSource(1))
+	g := func(x float64) float64 {
+		return (x-2)*(x-2) + 0.01*r.NormFloat64()
+	}
+	opts := NoiseOptions{Confidence: 0.99, Budget: 2000}
+	methods := map[string]func() (pkg.Result1D, float64){
+		"NoisyDichotomySearch": func() (pkg.Result1D, float64) {
+			return NoisyDichotomySearch(g, 0, 4, 0.02, 0.02, opts)
+		},
+		"NoisyGoldenSectionSearch": func() (pkg.Result1D, float64) {
+			return NoisyGoldenSectionSearch(g, 0, 4, 0.02, opts)
+		},
+	}
+	for name, search := range methods {
+		res, confidence := search()
+		if math.Abs(res.Xmin-2) > 0.1 {
+			t.Errorf("%s: xmin = %v, expected 2", name, res.Xmin)
+		}
+		if res.BFinal-res.AFinal > 0.04+1e-9 {
+			t.Errorf("%s: [%v, %v] is longer than 2*eps", name, res.AFinal, res.BFinal)
+		}
+		if confidence <= 0 || confidence > 1 {
+			t.Errorf("%s: confidence = %v, expected in (0, 1]", name, confidence)
+		}
+		if res.FEvals > opts.Budget {
+			t.Errorf("%s: evals = %v, budget %v", name, res.FEvals, opts.Budget)
+		}
+	}
+
+	// исчерпание бюджета прекращает поиск
+	res, _ = NoisyGoldenSectionSearch(g, 0, 4, 1e-6, NoiseOptions{Budget: 100})
+	if res.FEvals > 100 || res.BFinal-res.AFinal < 2e-6 || math.IsNaN(res.Fmin) || res.Reason != pkg.StopBudget {
+		t.Errorf("NoisyGoldenSectionSearch with budget: got %+v", res)
+	}
+}
+
+func TestUnimodalityMonitor(t *testing.T) {
+	methods := map[string]func(f func(float64) float64, a, b, eps float64){
+		"DichotomySearch": func(f func(float64) float64, a, b, eps float64) {
+			DichotomySearch(f, a, b, eps, eps/10)
+		},
+		"GoldenSectionSearch": func(f func(float64) float64, a, b, eps float64) {
+			GoldenSectionSearch(f, a, b, eps)
+		},
+		"FibonacciSearch": func(f func(float64) float64, a, b, eps float64) {
+			FibonacciSearch(f, a, b, eps)
+		},
+	}
+	unimodal := func(x float64) float64 {
+		return x + 2/x
+	}
+	multimodal := func(x float64) float64 {
+		return math.Sin(10*x) + x/4
+	}
+
+	for name, search := range methods {
+		m := NewUnimodalityMonitor(unimodal)
+		search(m.Eval, 0.5, 3.5, 1e-4)
+		if err := m.Check(); err != nil {
+			t.Errorf("%s: unexpected warning for unimodal function: %v", name, err)
+		}
+
+		m = NewUnimodalityMonitor(multimodal)
+		search(m.Eval, 0, 4, 1e-4)
+		err := m.Check()
+		var w *UnimodalityWarning
+		if !errors.As(err, &w) {
+			t.Fatalf("%s: expected UnimodalityWarning, got %v", name, err)
+		}
+		if !(w.Left.X < w.Peak.X && w.Peak.X < w.Right.X) || w.Peak.F <= max(w.Left.F, w.Right.F) {
+			t.Errorf("%s: inconsistent warning %+v", name, w)
+		}
+		if len(m.Points()) == 0 {
+			t.Errorf("%s: no points recorded", name)
+		}
 	}
 }
@@ -0,0 +1,290 @@
+package zeroordered
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/vshulcz/edu_optimization_methods/pkg"
+)
+
+// ErrBatchSize возвращается PassiveSearchBatch, если пакетная функция вернула
+// не столько значений, сколько получила точек.
+var ErrBatchSize = errors.New("batch returned wrong number of values")
+
+// NoiseOptions задаёт параметры статистического сравнения значений зашумлённой функции.
+// Нулевые значения полей заменяются значениями по умолчанию.
+type NoiseOptions struct {
+	// Confidence — требуемая достоверность каждого решения об отбрасывании части отрезка
+	// (по умолчанию 0.95).
+	Confidence float64
+	// MinSamples — число замеров в новой точке до первого сравнения (по умолчанию 5, не меньше 2).
+	MinSamples int
+	// MaxSamples — наибольшее число замеров в одной точке (по умолчанию 20 * MinSamples).
+	MaxSamples int
+	// Budget — общий бюджет вызовов f, включая MinSamples замеров в итоговой точке;
+	// 0 — без ограничения.
+	Budget int
+}
+
+func (o NoiseOptions) withDefaults() NoiseOptions {
+	if o.Confidence <= 0 || o.Confidence >= 1 {
+		o.Confidence = 0.95
+	}
+	if o.MinSamples == 0 {
+		o.MinSamples = 5
+	}
+	o.MinSamples = max(o.MinSamples, 2)
+	if o.MaxSamples < o.MinSamples {
+		o.MaxSamples = 20 * o.MinSamples
+	}
+	return o
+}
+
+// noisyPoint накапливает замеры значения функции в точке x.
+type noisyPoint struct {
+	x          float64
+	n          int
+	sum, sumSq float64
+}
+
+func (p *noisyPoint) mean() float64 {
+	return p.sum / float64(p.n)
+}
+
+// varianceOfMean — несмещённая оценка дисперсии среднего.
+func (p *noisyPoint) varianceOfMean() float64 {
+	if p.n < 2 {
+		return math.Inf(1)
+	}
+	m := p.mean()
+	v := (p.sumSq - float64(p.n)*m*m) / float64(p.n-1)
+	return max(v, 0) / float64(p.n)
+}
+
+// noisySampler вычисляет f с учётом общего бюджета вызовов.
+type noisySampler struct {
+	f      func(x float64) float64
+	opts   NoiseOptions
+	budget int
+	iters  int
+}
+
+// newNoisySampler резервирует из бюджета MinSamples вызовов на итоговую оценку fmin.
+func newNoisySampler(f func(x float64) float64, opts NoiseOptions) *noisySampler {
+	opts = opts.withDefaults()
+	s := &noisySampler{f: f, opts: opts}
+	if opts.Budget > 0 {
+		s.budget = max(opts.Budget-opts.MinSamples, 1)
+	}
+	return s
+}
+
+func (s *noisySampler) exhausted() bool {
+	return s.budget > 0 && s.iters >= s.budget
+}
+
+// result делает замеры в середине финального отрезка [a, b], используя зарезервированную
+// часть бюджета, и дополняет res.
+func (s *noisySampler) result(res pkg.Result1D, a, b, eps float64) pkg.Result1D {
+	res.Reason = pkg.StopInterval
+	if (b-a)/2.0 > eps {
+		res.Reason = pkg.StopBudget
+	}
+	if s.budget > 0 {
+		s.budget = max(s.opts.Budget, s.iters+1)
+	}
+	p := s.point((a + b) / 2.0)
+	res.Xmin, res.Fmin = p.x, p.mean()
+	res.AFinal, res.BFinal = a, b
+	res.FEvals = s.iters
+	return res
+}
+
+// sample добавляет замер в точке p; возвращает false, если бюджет исчерпан.
+func (s *noisySampler) sample(p *noisyPoint) bool {
+	if s.exhausted() {
+		return false
+	}
+	s.iters++
+	v := s.f(p.x)
+	p.n++
+	p.sum += v
+	p.sumSq += v * v
+	return true
+}
+
+// point создаёт точку x и делает в ней MinSamples замеров.
+func (s *noisySampler) point(x float64) *noisyPoint {
+	p := &noisyPoint{x: x}
+	for p.n < s.opts.MinSamples {
+		if !s.sample(p) {
+			break
+		}
+	}
+	return p
+}
+
+// less решает, меньше ли истинное значение в p, чем в q, по критерию Уэлча
+// (в нормальном приближении). Пока достоверность решения ниже opts.Confidence,
+// в обеих точках делаются дополнительные замеры — до MaxSamples или до исчерпания бюджета.
+// Возвращает решение и его достоверность Φ(|m_p - m_q| / σ).
+func (s *noisySampler) less(p, q *noisyPoint) (pLess bool, confidence float64) {
+	if p.n == 0 || q.n == 0 {
+		// бюджет исчерпан до первого замера
+		return q.n == 0, 0.5
+	}
+	for {
+		diff := q.mean() - p.mean()
+		se := math.Sqrt(p.varianceOfMean() + q.varianceOfMean())
+		// при нулевом разбросе замеров (детерминированная f) решение точное
+		confidence = 1
+		if se > 0 {
+			confidence = 0.5 * math.Erfc(-math.Abs(diff)/se/math.Sqrt2)
+		}
+		if confidence >= s.opts.Confidence || p.n >= s.opts.MaxSamples && q.n >= s.opts.MaxSamples {
+			return diff >= 0, confidence
+		}
+		sampled := false
+		if p.n < s.opts.MaxSamples {
+			sampled = s.sample(p) || sampled
+		}
+		if q.n < s.opts.MaxSamples {
+			sampled = s.sample(q) || sampled
+		}
+		if !sampled {
+			return diff >= 0, confidence
+		}
+	}
+}
+
+// Point — точка испытания x и значение функции в ней.
+type Point struct {
+	X, F float64
+}
+
+// UnimodalityWarning сообщает, что значения функции в испытанных точках противоречат
+// её унимодальности: между точками Left и Right лежит точка Peak, значение в которой больше,
+// чем в обеих. Результат методов сокращения отрезка в этом случае ненадёжен.
+type UnimodalityWarning struct {
+	Left, Peak, Right Point
+}
+
+func (w *UnimodalityWarning) Error() string {
+	return fmt.Sprintf("function is not unimodal: f(%g) = %g exceeds f(%g) = %g and f(%g) = %g",
+		w.Peak.X, w.Peak.F, w.Left.X, w.Left.F, w.Right.X, w.Right.F)
+}
+
+// UnimodalityMonitor — диагностический режим для методов сокращения отрезка
+// (DichotomySearch, GoldenSectionSearch, FibonacciSearch и др.).
+//
+// Монитор оборачивает функцию f и запоминает все точки, в которых она вычислялась.
+// После поиска метод Check проверяет, согласуются ли запомненные значения с унимодальностью:
+// у унимодальной функции значения слева направо сначала не возрастают, а затем не убывают,
+// то есть ни одна точка не может быть выше наименьших значений и слева, и справа от неё.
+//
+// Использование:
+//
+//	m := NewUnimodalityMonitor(f)
+//	res := GoldenSectionSearch(m.Eval, a, b, eps)
+//	if err := m.Check(); err != nil {
+//		// f не унимодальна на [a, b]: перейти к PassiveSearch или PiyavskiiSearch
+//	}
+//
+// Проверка необходимая, но не достаточная: отсутствие предупреждения не доказывает
+// унимодальность, а лишь означает, что испытания ей не противоречат.
+type UnimodalityMonitor struct {
+	// Tol — допуск на погрешность вычисления f: точка считается "пиком",
+	// только если превышает соседние минимумы больше чем на Tol.
+	Tol float64
+
+	f      func(x float64) float64
+	points []Point
+}
+
+// NewUnimodalityMonitor создаёт монитор для функции f.
+func NewUnimodalityMonitor(f func(x float64) float64) *UnimodalityMonitor {
+	return &UnimodalityMonitor{f: f}
+}
+
+// Eval вычисляет f(x) и запоминает точку.
+func (m *UnimodalityMonitor) Eval(x float64) float64 {
+	v := m.f(x)
+	m.points = append(m.points, Point{X: x, F: v})
+	return v
+}
+
+// Points возвращает все испытанные точки в порядке возрастания x.
+func (m *UnimodalityMonitor) Points() []Point {
+	points := append([]Point(nil), m.points...)
+	sort.SliceStable(points, func(i, j int) bool { return points[i].X < points[j].X })
+	return points
+}
+
+// Check возвращает *UnimodalityWarning с наиболее выраженным нарушением унимодальности
+// или nil, если испытания ей не противоречат.
+func (m *UnimodalityMonitor) Check() error {
+	points := m.Points()
+	n := len(points)
+	if n < 3 {
+		return nil
+	}
+
+	// индексы наименьших значений слева (включительно) и справа (включительно)
+	left := make([]int, n)
+	right := make([]int, n)
+	for i := 1; i < n; i++ {
+		left[i] = left[i-1]
+		if points[i].F < points[left[i]].F {
+			left[i] = i
+		}
+	}
+	right[n-1] = n - 1
+	for i := n - 2; i >= 0; i-- {
+		right[i] = right[i+1]
+		if points[i].F < points[right[i]].F {
+			right[i] = i
+		}
+	}
+
+	var warning *UnimodalityWarning
+	worst := 0.0
+	for k := 1; k+1 < n; k++ {
+		l, r := points[left[k-1]], points[right[k+1]]
+		excess := points[k].F - max(l.F, r.F)
+		if excess > m.Tol && excess > worst {
+			worst = excess
+			warning = &UnimodalityWarning{Left: l, Peak: points[k], Right: r}
+		}
+	}
+	if warning == nil {
+		return nil
+	}
+	return warning
+}
+
+// Реализации pkg.LineMinimizer на основе методов пакета,
+// для передачи в многомерные методы в качестве одномерного поиска шага.
+var (
+	GoldenSection pkg.LineMinimizer = pkg.LineMinimizerFunc(GoldenSectionSearch)
+	Fibonacci     pkg.LineMinimizer = pkg.LineMinimizerFunc(FibonacciSearch)
+	Passive       pkg.LineMinimizer = pkg.LineMinimizerFunc(PassiveSearch)
+	Brent         pkg.LineMinimizer = pkg.LineMinimizerFunc(BrentSearch)
+	Parabolic     pkg.LineMinimizer = pkg.LineMinimizerFunc(ParabolicSearch)
+)
+
+// Dichotomy — pkg.LineMinimizer на основе DichotomySearch.
+// Delta — расстояние между пробными точками; при Delta = 0 используется eps/10.
+type Dichotomy struct {
+	Delta float64
+}
+
+// Minimize вызывает DichotomySearch с параметром d.Delta.
+func (d Dichotomy) Minimize(f func(x float64) float64, a, b, eps float64) pkg.Result1D {
+	delta := d.Delta
+	if delta == 0 {
+		delta = eps / 10
+	}
+	return DichotomySearch(f, a, b, eps, delta)
+}
@@ -2,6 +2,8 @@ package highordered
 
 import (
 	"math"
+
+	"github.com/vshulcz/edu_optimization_methods/pkg"
 )
 
 // TangentSearch реализует метод касательных (метод хорд) для поиска минимума функции f
@@ -24,38 +26,85 @@ import (
 // - Использует первую производную функции — метод первого порядка.
 // - Эффективен для выпуклых и гладких функций, где f'(a) < 0 и f'(b) > 0.
 // - Быстрая сходимость при хорошо заданных начальных условиях.
+// - Число итераций ограничено maxIter (при maxIter ≤ 0 — DefaultMaxIter).
+// - Если tr != nil, в трассу записываются точки пересечения касательных x0 (концы a, b — нет).
 //
-// Возвращает xmin — найденную стационарную точку, fmin — значение функции в ней,
-// и iters — количество вызовов f.
+// Возвращает pkg.Result1D: xmin — найденную стационарную точку, fmin — значение функции в ней,
+// финальный отрезок [a, b], число итераций, вызовов f и df и причину остановки.
+// Ошибка err равна:
+// - ErrNotBracketed, если a ≥ b, f'(a) ≥ 0 и f'(b) ≤ 0 одновременно или точка x0 вышла за [a, b] (f невыпукла);
+// - ErrDiverged, если x0 не является конечным числом;
+// - ErrStagnation, если x0 совпала с концом отрезка и отрезок перестал сужаться;
+// - ErrMaxIter, если за maxIter итераций условие остановы не выполнено.
 func TangentSearch(
 	f func(x float64) float64,
 	df func(x float64) float64,
 	a, b, eps float64,
-) (xmin, fmin float64, iters int) {
+	maxIter int,
+	tr *pkg.Trace,
+) (res pkg.Result1D, err error) {
+	maxIter = iterLimit(maxIter)
 	phiF := func(x_ float64) float64 {
-		iters++
+		res.FEvals++
 		return f(x_)
 	}
+	phiDF := func(x_ float64) float64 {
+		res.DFEvals++
+		return df(x_)
+	}
 
-	fa, fb := df(a), df(b)
+	res.AFinal, res.BFinal = a, b
+	res.Reason = pkg.StopBoundary
+	fa, fb := phiDF(a), phiDF(b)
+	if a >= b || fa >= 0 && fb <= 0 {
+		res.Xmin, res.Fmin = a, phiF(a)
+		res.Reason = pkg.StopNotBracketed
+		return res, ErrNotBracketed
+	}
 	if fa >= 0 {
-		return a, phiF(a), 0
+		res.Xmin, res.Fmin = a, phiF(a)
+		return
 	}
 	if fb <= 0 {
-		return b, phiF(b), 0
+		res.Xmin, res.Fmin = b, phiF(b)
+		return
 	}
 
-	var x0 float64
+	x0 := a
 	for {
-		m1 := df(a)
-		m2 := df(b)
+		if res.Iters >= maxIter {
+			res.Reason, err = pkg.StopMaxIter, ErrMaxIter
+			break
+		}
+		res.Iters++
+		m1 := phiDF(a)
+		m2 := phiDF(b)
 		c1 := phiF(a) - m1*a
 		c2 := phiF(b) - m2*b
 
-		x0 = (c2 - c1) / (m1 - m2)
-		dfx0 := df(x0)
+		x := (c2 - c1) / (m1 - m2)
+		if !finite(x) {
+			res.Reason, err = pkg.StopDiverged, ErrDiverged
+			break
+		}
+		if x < a || x > b {
+			res.Reason, err = pkg.StopNotBracketed, ErrNotBracketed
+			break
+		}
+		x0 = x
+		tr.Add(x0)
+		dfx0 := phiDF(x0)
 
-		if math.Abs(b-a) <= eps || math.Abs(dfx0) <= eps {
+		if math.Abs(b-a) <= eps {
+			res.Reason = pkg.StopInterval
+			break
+		}
+		if math.Abs(dfx0) <= eps {
+			res.Reason = pkg.StopGradient
+			break
+		}
+		if x0 == a || x0 == b {
+			res.Reason, err = pkg.StopStagnation, ErrStagnation
 			break
 		}
 
@@ -65,8 +114,9 @@ func TangentSearch(
 			a = x0
 		}
 	}
-	xmin = x0
-	fmin = phiF(x0)
+	res.Xmin = x0
+	res.Fmin = phiF(x0)
+	res.AFinal, res.BFinal = a, b
 	return
 }
 
@@ -82,41 +132,75 @@ func TangentSearch(
 //
 // Условия остановы:
 // - |f'(x)| ≤ eps — достигнута стационарная точка (точность по производной)
-// - f”(x) = 0 — невозможность деления, итерации прекращаются
+// - f”(x) = 0 — невозможность деления, итерации прекращаются с ошибкой ErrZeroCurvature
+// - x_{k+1} не конечно или |x_{k+1}| > 2^52·max(|x0|, 1) — ошибка ErrDiverged (Xmin остаётся равным x_k)
+// - x_{k+1} = x_k при |f'(x_k)| > eps — ошибка ErrStagnation
+// - выполнено maxIter итераций (при maxIter ≤ 0 — DefaultMaxIter) — ошибка ErrMaxIter
 //
 // Особенности:
 // - Метод второго порядка: при хороших начальных условиях сходится квадратично.
 // - Требует знание второй производной функции.
 // - Не гарантирует сходимость, особенно при плохом начальном приближении или если f”(x) близко к нулю.
 // - Подходит для задач, где f(x) дважды дифференцируема и минимум — стационарная точка.
+// - Если tr != nil, в трассу записываются приближения x_0, x_1, ....
 //
-// Возвращает xmin — точку, где f'(x) ≈ 0, fmin — значение функции в ней,
-// и iters — количество вызовов f.
+// Возвращает pkg.Result1D: xmin — точку, где f'(x) ≈ 0, fmin — значение функции в ней,
+// отрезок между двумя последними приближениями, число итераций, вызовов f, df и d2f
+// и причину остановки, а также ошибку, если метод не сошёлся.
 func NewtonSearch(
 	f func(x float64) float64,
 	df func(x float64) float64,
 	d2f func(x float64) float64,
 	x0, eps float64,
-) (xmin, fmin float64, iters int) {
+	maxIter int,
+	tr *pkg.Trace,
+) (res pkg.Result1D, err error) {
+	maxIter = iterLimit(maxIter)
 	phiF := func(x_ float64) float64 {
-		iters++
+		res.FEvals++
 		return f(x_)
 	}
+	phiDF := func(x_ float64) float64 {
+		res.DFEvals++
+		return df(x_)
+	}
+	phiD2F := func(x_ float64) float64 {
+		res.D2FEvals++
+		return d2f(x_)
+	}
 
-	x := x0
+	x, xPrev := x0, x0
 	for {
-		g := df(x)
+		tr.Add(x)
+		g := phiDF(x)
 		if math.Abs(g) <= eps {
+			res.Reason = pkg.StopGradient
+			break
+		}
+		if res.Iters >= maxIter {
+			res.Reason, err = pkg.StopMaxIter, ErrMaxIter
 			break
 		}
-		h := d2f(x)
+		h := phiD2F(x)
 		if h == 0 {
+			res.Reason, err = pkg.StopZeroCurvature, ErrZeroCurvature
+			break
+		}
+		xNext := x - g/h
+		if diverged(xNext, math.Abs(x0)) {
+			res.Reason, err = pkg.StopDiverged, ErrDiverged
+			break
+		}
+		if xNext == x {
+			res.Reason, err = pkg.StopStagnation, ErrStagnation
 			break
 		}
-		x = x - g/h
+		res.Iters++
+		xPrev, x = x, xNext
 	}
-	xmin = x
-	fmin = phiF(x)
+	res.Xmin = x
+	res.Fmin = phiF(x)
+	res.AFinal, res.BFinal = min(x, xPrev), max(x, xPrev)
 	return
 }
 
@@ -135,40 +219,585 @@ func NewtonSearch(
 //   - На каждой итерации вычисляется новое приближение x2 как точка пересечения секущей
 //     между (x_k, f'(x_k)) и (x_{k-1}, f'(x_{k-1})) с осью X.
 //   - Процесс повторяется, пока |f'(x_k)| ≤ eps или знаменатель не станет нулевым.
+//   - Нулевой знаменатель при x_k ≠ x_{k-1} даёт ошибку ErrZeroCurvature, при x_k = x_{k-1} — ErrStagnation;
+//     неконечное или слишком далёкое от x0, x1 приближение — ErrDiverged, превышение maxIter итераций
+//     (при maxIter ≤ 0 — DefaultMaxIter) — ErrMaxIter.
 //
 // Особенности:
 // - Метод первого порядка (медленнее Ньютона, но не требует f”).
 // - Чувствителен к выбору начальных приближений.
 // - Может расходиться или зациклиться при плохом выборе x0, x1.
+// - Если tr != nil, в трассу записываются приближения x_0, x_1, x_2, ....
 //
-// Возвращает xmin — приближение к стационарной точке, fmin — значение функции в ней,
-// и iters — количество вызовов функции f.
+// Возвращает pkg.Result1D: xmin — приближение к стационарной точке, fmin — значение функции в ней,
+// отрезок между двумя последними приближениями, число итераций, вызовов f и df
+// и причину остановки, а также ошибку, если метод не сошёлся.
 func SecantSearch(
 	f func(x float64) float64,
 	df func(x float64) float64,
 	x0, x1, eps float64,
-) (xmin, fmin float64, iters int) {
+	maxIter int,
+	tr *pkg.Trace,
+) (res pkg.Result1D, err error) {
+	maxIter = iterLimit(maxIter)
 	phiF := func(x_ float64) float64 {
-		iters++
+		res.FEvals++
 		return f(x_)
 	}
+	phiDF := func(x_ float64) float64 {
+		res.DFEvals++
+		return df(x_)
+	}
 
-	f0 := df(x0)
+	scale := max(math.Abs(x0), math.Abs(x1))
+	tr.Add(x0)
+	f0 := phiDF(x0)
 	for {
-		f1 := df(x1)
+		tr.Add(x1)
+		f1 := phiDF(x1)
 		if math.Abs(f1) <= eps {
-			xmin = x1
+			res.Reason = pkg.StopGradient
+			break
+		}
+		if res.Iters >= maxIter {
+			res.Reason, err = pkg.StopMaxIter, ErrMaxIter
+			break
+		}
+		if x1 == x0 {
+			res.Reason, err = pkg.StopStagnation, ErrStagnation
 			break
 		}
 		denom := f1 - f0
 		if denom == 0 {
+			res.Reason, err = pkg.StopZeroDenominator, ErrZeroCurvature
 			break
 		}
 		x2 := x1 - (x1-x0)*f1/denom
+		if diverged(x2, scale) {
+			res.Reason, err = pkg.StopDiverged, ErrDiverged
+			break
+		}
+		res.Iters++
 		x0, f0 = x1, f1
 		x1 = x2
 	}
-	xmin = x1
-	fmin = phiF(x1)
+	res.Xmin = x1
+	res.Fmin = phiF(x1)
+	res.AFinal, res.BFinal = min(x0, x1), max(x0, x1)
 	return
 }
+
+// SafeguardedNewtonSearch реализует метод Ньютона с защитой (safeguarded Newton)
+// для поиска минимума функции f на отрезке [a, b], решая уравнение f'(x) = 0
+// с использованием производных df и d2f.
+//
+// В отличие от NewtonSearch, метод хранит отрезок [a, b], на концах которого f'
+// имеет разные знаки (f'(a) < 0 < f'(b)), поэтому минимум всегда остаётся локализованным.
+//
+// Алгоритм:
+//   - Если df(a) ≥ 0, то минимум находится в точке a; если df(b) ≤ 0 — в точке b.
+//   - Начальное приближение — середина отрезка.
+//   - По знаку f'(x) отрезок сужается: если f'(x) < 0, то a = x; иначе b = x.
+//   - Вычисляется шаг Ньютона x_N = x - f'(x) / f”(x). Он принимается, если f”(x) > 0,
+//     x_N лежит строго внутри (a, b) и |f'(x_N)| < |f'(x)|.
+//   - Иначе выполняется шаг деления отрезка пополам: x = (a + b) / 2.
+//   - Процесс повторяется до выполнения одного из условий остановы:
+//     |f'(x)| ≤ eps или b - a ≤ eps.
+//
+// Особенности:
+// - Вблизи решения сходится квадратично, как метод Ньютона.
+// - Не уходит к максимуму при f”(x) < 0 и не останавливается при f”(x) = 0.
+// - Гарантирует сходимость для любой непрерывно дифференцируемой f со сменой знака f' на [a, b].
+// - Число итераций ограничено maxIter (при maxIter ≤ 0 — DefaultMaxIter).
+//
+// Возвращает pkg.Result1D: xmin — найденную стационарную точку, fmin — значение функции в ней,
+// финальный отрезок [a, b], число итераций, вызовов f, df и d2f и причину остановки.
+// Ошибка err равна ErrMaxIter, если за maxIter итераций условие остановы не выполнено
+// (например, если из-за неверной d2f шаги Ньютона отвергаются, а eps меньше, чем позволяет деление пополам).
+func SafeguardedNewtonSearch(
+	f func(x float64) float64,
+	df func(x float64) float64,
+	d2f func(x float64) float64,
+	a, b, eps float64,
+	maxIter int,
+) (res pkg.Result1D, err error) {
+	maxIter = iterLimit(maxIter)
+	phiF := func(x_ float64) float64 {
+		res.FEvals++
+		return f(x_)
+	}
+	phiDF := func(x_ float64) float64 {
+		res.DFEvals++
+		return df(x_)
+	}
+	phiD2F := func(x_ float64) float64 {
+		res.D2FEvals++
+		return d2f(x_)
+	}
+
+	res.AFinal, res.BFinal = a, b
+	res.Reason = pkg.StopBoundary
+	if phiDF(a) >= 0 {
+		res.Xmin, res.Fmin = a, phiF(a)
+		return
+	}
+	if phiDF(b) <= 0 {
+		res.Xmin, res.Fmin = b, phiF(b)
+		return
+	}
+
+	x := (a + b) / 2
+	g := phiDF(x)
+	for {
+		if math.Abs(g) <= eps {
+			res.Reason = pkg.StopGradient
+			break
+		}
+		if g < 0 {
+			a = x
+		} else {
+			b = x
+		}
+		if b-a <= eps {
+			res.Reason = pkg.StopInterval
+			break
+		}
+		if res.Iters >= maxIter {
+			res.Reason, err = pkg.StopMaxIter, ErrMaxIter
+			break
+		}
+		res.Iters++
+
+		if h := phiD2F(x); h > 0 {
+			xn := x - g/h
+			if a < xn && xn < b {
+				if gn := phiDF(xn); math.Abs(gn) < math.Abs(g) {
+					x, g = xn, gn
+					continue
+				}
+			}
+		}
+
+		mid := (a + b) / 2
+		if mid <= a || mid >= b {
+			res.Reason = pkg.StopDegenerate
+			break
+		}
+		x = mid
+		g = phiDF(x)
+	}
+	res.Xmin = x
+	res.Fmin = phiF(x)
+	res.AFinal, res.BFinal = a, b
+	return
+}
+
+// HalleySearch реализует метод Галлея для поиска стационарной точки функции f,
+// т.е. решения уравнения f'(x) = 0, с использованием производных df, d2f и d3f.
+//
+// Метод применяет к уравнению f'(x) = 0 метод касательных гипербол:
+// x_{k+1} = x_k - 2 f'(x_k) f”(x_k) / (2 f”(x_k)² - f'(x_k) f”'(x_k))
+//
+// Условия остановы:
+// - |f'(x)| ≤ eps — достигнута стационарная точка (точность по производной)
+// - знаменатель формулы обратился в нуль — ошибка ErrZeroCurvature
+// - x_{k+1} не конечно или |x_{k+1}| > 2^52·max(|x0|, 1) — ошибка ErrDiverged (Xmin остаётся равным x_k)
+// - x_{k+1} = x_k при |f'(x_k)| > eps — ошибка ErrStagnation
+// - выполнено maxIter итераций (при maxIter ≤ 0 — DefaultMaxIter) — ошибка ErrMaxIter
+//
+// Особенности:
+// - Метод третьего порядка: вблизи решения число верных знаков утраивается на каждой итерации.
+// - Требует третью производную функции.
+// - Как и метод Ньютона, не гарантирует сходимость при плохом начальном приближении.
+// - Если tr != nil, в трассу записываются приближения x_0, x_1, ....
+//
+// Возвращает pkg.Result1D: xmin — точку, где f'(x) ≈ 0, fmin — значение функции в ней,
+// отрезок между двумя последними приближениями, число итераций, вызовов f, df, d2f и d3f
+// и причину остановки, а также ошибку, если метод не сошёлся.
+func HalleySearch(
+	f func(x float64) float64,
+	df func(x float64) float64,
+	d2f func(x float64) float64,
+	d3f func(x float64) float64,
+	x0, eps float64,
+	maxIter int,
+	tr *pkg.Trace,
+) (pkg.Result1D, error) {
+	halley := func(g, h, t float64) (step float64, ok bool) {
+		denom := 2*h*h - g*t
+		if denom == 0 {
+			return 0, false
+		}
+		return 2 * g * h / denom, true
+	}
+	return thirdOrderSearch(f, df, d2f, d3f, x0, eps, maxIter, tr, halley, pkg.StopZeroDenominator)
+}
+
+// ChebyshevSearch реализует метод Чебышёва для поиска стационарной точки функции f,
+// т.е. решения уравнения f'(x) = 0, с использованием производных df, d2f и d3f.
+//
+// Формула метода получается из разложения обратной к f' функции до второго порядка:
+// x_{k+1} = x_k - f'(x_k) / f”(x_k) - f”'(x_k) f'(x_k)² / (2 f”(x_k)³)
+//
+// Условия остановы — как у HalleySearch, но ErrZeroCurvature возвращается при f”(x) = 0.
+//
+// Особенности:
+// - Метод третьего порядка, как и метод Галлея.
+// - Первое слагаемое — шаг Ньютона, второе — поправка на кривизну f'.
+// - Не гарантирует сходимость при плохом начальном приближении.
+// - Трасса tr записывается так же, как у HalleySearch.
+//
+// Возвращает pkg.Result1D и ошибку так же, как HalleySearch.
+func ChebyshevSearch(
+	f func(x float64) float64,
+	df func(x float64) float64,
+	d2f func(x float64) float64,
+	d3f func(x float64) float64,
+	x0, eps float64,
+	maxIter int,
+	tr *pkg.Trace,
+) (pkg.Result1D, error) {
+	chebyshev := func(g, h, t float64) (step float64, ok bool) {
+		if h == 0 {
+			return 0, false
+		}
+		newton := g / h
+		return newton + t*newton*newton/(2*h), true
+	}
+	return thirdOrderSearch(f, df, d2f, d3f, x0, eps, maxIter, tr, chebyshev, pkg.StopZeroCurvature)
+}
+
+// thirdOrderSearch — общий цикл методов третьего порядка: x_{k+1} = x_k - step(f', f”, f”').
+// Если шаг вычислить невозможно (step вернул ok = false), итерации прекращаются с причиной stopReason
+// и ошибкой ErrZeroCurvature; остальные ошибки — как у NewtonSearch.
+func thirdOrderSearch(
+	f func(x float64) float64,
+	df func(x float64) float64,
+	d2f func(x float64) float64,
+	d3f func(x float64) float64,
+	x0, eps float64,
+	maxIter int,
+	tr *pkg.Trace,
+	step func(g, h, t float64) (float64, bool),
+	stopReason pkg.StopReason,
+) (res pkg.Result1D, err error) {
+	maxIter = iterLimit(maxIter)
+	phiF := func(x_ float64) float64 {
+		res.FEvals++
+		return f(x_)
+	}
+	phiDF := func(x_ float64) float64 {
+		res.DFEvals++
+		return df(x_)
+	}
+	phiD2F := func(x_ float64) float64 {
+		res.D2FEvals++
+		return d2f(x_)
+	}
+	phiD3F := func(x_ float64) float64 {
+		res.D3FEvals++
+		return d3f(x_)
+	}
+
+	x, xPrev := x0, x0
+	for {
+		tr.Add(x)
+		g := phiDF(x)
+		if math.Abs(g) <= eps {
+			res.Reason = pkg.StopGradient
+			break
+		}
+		if res.Iters >= maxIter {
+			res.Reason, err = pkg.StopMaxIter, ErrMaxIter
+			break
+		}
+		dx, ok := step(g, phiD2F(x), phiD3F(x))
+		if !ok {
+			res.Reason, err = stopReason, ErrZeroCurvature
+			break
+		}
+		xNext := x - dx
+		if diverged(xNext, math.Abs(x0)) {
+			res.Reason, err = pkg.StopDiverged, ErrDiverged
+			break
+		}
+		if xNext == x {
+			res.Reason, err = pkg.StopStagnation, ErrStagnation
+			break
+		}
+		res.Iters++
+		xPrev, x = x, xNext
+	}
+	res.Xmin = x
+	res.Fmin = phiF(x)
+	res.AFinal, res.BFinal = min(x, xPrev), max(x, xPrev)
+	return
+}
+
+// RegulaFalsiSearch реализует метод ложного положения (regula falsi) для поиска минимума
+// функции f на отрезке [a, b], решая уравнение f'(x) = 0 с использованием производной df.
+//
+// В отличие от SecantSearch, метод хранит отрезок [a, b], на концах которого f'
+// имеет разные знаки, и заменяет тот конец, где знак f' совпадает со знаком в новой точке.
+//
+// Алгоритм:
+//   - Если df(a) ≥ 0, то минимум находится в точке a; если df(b) ≤ 0 — в точке b.
+//   - Новая точка — пересечение секущей через (a, f'(a)) и (b, f'(b)) с осью X:
+//     x = (a·f'(b) - b·f'(a)) / (f'(b) - f'(a)).
+//   - Если f'(x) > 0, то b = x; иначе a = x.
+//   - Процесс повторяется до выполнения одного из условий остановы:
+//     |f'(x)| ≤ eps или b - a ≤ eps.
+//
+// Особенности:
+// - Сходимость гарантирована, так как минимум всегда остаётся на отрезке.
+// - Для выпуклой f' один из концов перестаёт двигаться, и сходимость становится линейной.
+// - Число итераций ограничено maxIter (при maxIter ≤ 0 — DefaultMaxIter).
+//
+// Возвращает pkg.Result1D: xmin — найденную стационарную точку, fmin — значение функции в ней,
+// финальный отрезок [a, b], число итераций, вызовов f и df и причину остановки.
+// Ошибка err равна ErrMaxIter, если за maxIter итераций условие остановы не выполнено.
+func RegulaFalsiSearch(
+	f func(x float64) float64,
+	df func(x float64) float64,
+	a, b, eps float64,
+	maxIter int,
+) (pkg.Result1D, error) {
+	return falsePositionSearch(f, df, a, b, eps, maxIter, func(gNew, gOld float64) float64 {
+		return 1
+	})
+}
+
+// IllinoisSearch реализует модификацию Illinois метода ложного положения.
+//
+// Если один и тот же конец отрезка сохраняется две итерации подряд, значение f' в нём
+// делится пополам. Это сдвигает следующую точку к неподвижному концу и возвращает
+// сверхлинейную сходимость (порядок ≈ 1.442).
+//
+// Параметры, условия остановы, результат и ошибки — как у RegulaFalsiSearch.
+func IllinoisSearch(
+	f func(x float64) float64,
+	df func(x float64) float64,
+	a, b, eps float64,
+	maxIter int,
+) (pkg.Result1D, error) {
+	return falsePositionSearch(f, df, a, b, eps, maxIter, func(gNew, gOld float64) float64 {
+		return 0.5
+	})
+}
+
+// AndersonBjorckSearch реализует модификацию Андерсона–Бьорка метода ложного положения.
+//
+// Если один и тот же конец отрезка сохраняется две итерации подряд, значение f' в нём
+// умножается на m = 1 - f'(x) / f'(x_old), где x_old — заменённый конец;
+// если m ≤ 0, используется m = 1/2, как в методе Illinois.
+// Множитель m соответствует параболе через три последние точки,
+// поэтому метод обычно сходится быстрее Illinois (порядок ≈ 1.7).
+//
+// Параметры, условия остановы, результат и ошибки — как у RegulaFalsiSearch.
+func AndersonBjorckSearch(
+	f func(x float64) float64,
+	df func(x float64) float64,
+	a, b, eps float64,
+	maxIter int,
+) (pkg.Result1D, error) {
+	return falsePositionSearch(f, df, a, b, eps, maxIter, func(gNew, gOld float64) float64 {
+		if m := 1 - gNew/gOld; m > 0 {
+			return m
+		}
+		return 0.5
+	})
+}
+
+// falsePositionSearch — общий цикл метода ложного положения и его модификаций.
+// Если один и тот же конец отрезка сохраняется две итерации подряд, значение f' в нём
+// умножается на scale(f'(x), f'(x_old)), где x_old — заменяемый конец.
+func falsePositionSearch(
+	f func(x float64) float64,
+	df func(x float64) float64,
+	a, b, eps float64,
+	maxIter int,
+	scale func(gNew, gOld float64) float64,
+) (res pkg.Result1D, err error) {
+	maxIter = iterLimit(maxIter)
+	phiF := func(x_ float64) float64 {
+		res.FEvals++
+		return f(x_)
+	}
+	phiDF := func(x_ float64) float64 {
+		res.DFEvals++
+		return df(x_)
+	}
+
+	res.AFinal, res.BFinal = a, b
+	res.Reason = pkg.StopBoundary
+	ga, gb := phiDF(a), phiDF(b)
+	if ga >= 0 {
+		res.Xmin, res.Fmin = a, phiF(a)
+		return
+	}
+	if gb <= 0 {
+		res.Xmin, res.Fmin = b, phiF(b)
+		return
+	}
+
+	// side — какой конец был заменён на предыдущей итерации: -1 — a, +1 — b
+	var x float64
+	side := 0
+	for {
+		if res.Iters >= maxIter {
+			res.Reason, err = pkg.StopMaxIter, ErrMaxIter
+			break
+		}
+		res.Iters++
+		x = (a*gb - b*ga) / (gb - ga)
+		if x <= a || x >= b {
+			res.Reason = pkg.StopDegenerate
+			break
+		}
+		gx := phiDF(x)
+		if math.Abs(gx) <= eps {
+			res.Reason = pkg.StopGradient
+			break
+		}
+
+		if gx > 0 {
+			if side == +1 {
+				ga *= scale(gx, gb)
+			}
+			b, gb = x, gx
+			side = +1
+		} else {
+			if side == -1 {
+				gb *= scale(gx, ga)
+			}
+			a, ga = x, gx
+			side = -1
+		}
+
+		if b-a <= eps {
+			res.Reason = pkg.StopInterval
+			break
+		}
+	}
+	res.Xmin = x
+	res.Fmin = phiF(x)
+	res.AFinal, res.BFinal = a, b
+	return
+}
+
+// CubicSearch реализует метод кубической интерполяции (метод Давидона) для поиска минимума
+// функции f на отрезке [a, b] с использованием её производной df.
+//
+// По значениям f и f' в двух точках a и b строится кубический полином Эрмита,
+// и следующая точка — его минимум:
+//
+//	z = 3(f(a) - f(b)) / (b - a) + f'(a) + f'(b)
+//	w = √(z² - f'(a)·f'(b))
+//	x = b - (b - a)·(f'(b) + w - z) / (f'(b) - f'(a) + 2w)
+//
+// Алгоритм:
+//   - Если df(a) ≥ 0, то минимум находится в точке a; если df(b) ≤ 0 — в точке b.
+//   - Иначе f'(a) < 0 < f'(b), и минимум лежит внутри (a, b).
+//   - Кубика строится по двум последним точкам испытаний; если её минимум не попал
+//     внутрь (a, b), кубика строится по концам отрезка.
+//   - Защита: во втором случае точка x сдвигается внутрь отрезка не ближе cubicGuard·(b - a)
+//     к концам, а при нечисловом результате заменяется серединой отрезка.
+//   - Если f'(x) ≥ 0, то b = x; иначе a = x. Так сохраняется f'(a) < 0 < f'(b), и отрезок
+//     содержит локальный минимум, даже если f не унимодальна (f(x) ≥ f(a) при f'(x) < 0).
+//   - Процесс повторяется до выполнения одного из условий остановы:
+//     |f'(x)| ≤ eps или b - a ≤ eps.
+//
+// Особенности:
+// - Использует и f, и f' в двух точках — вдвое больше информации, чем метод секущих.
+// - Для квадратичных и кубических функций находит минимум за одну итерацию.
+// - Подходит для поиска шага α в многомерных методах: φ'(α) = ⟨∇f(x + αd), d⟩ известна вместе с градиентом.
+//
+// Возвращает pkg.Result1D: xmin — найденную точку минимума, fmin — значение функции в ней,
+// финальный отрезок [a, b], число итераций, вызовов f и df и причину остановки.
+func CubicSearch(
+	f func(x float64) float64,
+	df func(x float64) float64,
+	a, b, eps float64,
+) (res pkg.Result1D) {
+	phiF := func(x_ float64) float64 {
+		res.FEvals++
+		return f(x_)
+	}
+	phiDF := func(x_ float64) float64 {
+		res.DFEvals++
+		return df(x_)
+	}
+
+	res.AFinal, res.BFinal = a, b
+	res.Reason = pkg.StopBoundary
+	ga, gb := phiDF(a), phiDF(b)
+	if ga >= 0 {
+		res.Xmin, res.Fmin = a, phiF(a)
+		return
+	}
+	if gb <= 0 {
+		res.Xmin, res.Fmin = b, phiF(b)
+		return
+	}
+
+	fa, fb := phiF(a), phiF(b)
+	// две последние точки испытаний (p — предыдущая, q — текущая)
+	p, fp, gp := a, fa, ga
+	q, fq, gq := b, fb, gb
+	for {
+		res.Iters++
+		x := cubicMin(p, fp, gp, q, fq, gq)
+		if !(x > a && x < b) {
+			x = cubicMin(a, fa, ga, b, fb, gb)
+			guard := cubicGuard * (b - a)
+			switch {
+			case math.IsNaN(x) || math.IsInf(x, 0):
+				x = (a + b) / 2
+			case x < a+guard:
+				x = a + guard
+			case x > b-guard:
+				x = b - guard
+			}
+		}
+		if x <= a || x >= b {
+			res.Reason = pkg.StopDegenerate
+			break
+		}
+
+		fx, gx := phiF(x), phiDF(x)
+		p, fp, gp = q, fq, gq
+		q, fq, gq = x, fx, gx
+		if math.Abs(gx) <= eps {
+			res.Reason = pkg.StopGradient
+			break
+		}
+		// b заменяется только точкой с f'(x) ≥ 0, иначе нарушится f'(a) < 0 < f'(b);
+		// при f'(x) < 0 и f(x) ≥ f(a) f не унимодальна, но на (x, b) минимум остаётся
+		if gx >= 0 {
+			b, fb, gb = x, fx, gx
+		} else {
+			a, fa, ga = x, fx, gx
+		}
+		if b-a <= eps {
+			res.Reason = pkg.StopInterval
+			break
+		}
+	}
+	res.Xmin, res.Fmin = q, fq
+	res.AFinal, res.BFinal = a, b
+	return
+}
+
+// cubicMin возвращает точку минимума кубического полинома Эрмита,
+// построенного по значениям и производным в точках a и b.
+func cubicMin(a, fa, ga, b, fb, gb float64) float64 {
+	z := 3*(fa-fb)/(b-a) + ga + gb
+	w := math.Sqrt(z*z - ga*gb)
+	if a > b {
+		w = -w
+	}
+	return b - (b-a)*(gb+w-z)/(gb-ga+2*w)
+}
+
+// cubicGuard — минимальное относительное расстояние от новой точки CubicSearch до концов отрезка.
+const cubicGuard = 0.01
@@ -1,8 +1,11 @@
 package highordered
 
 import (
+	"errors"
 	"math"
 	"testing"
+
+	"github.com/vshulcz/edu_optimization_methods/pkg"
 )
 
 func TestTangentSearch(t *testing.T) {
@@ -14,11 +17,12 @@ func TestTangentSearch(t *testing.T) {
 		eps float64
 	}
 	tests := []struct {
-		name      string
-		args      args
-		wantXmin  float64
-		wantFmin  float64
-		wantIters int
+		name       string
+		args       args
+		wantXmin   float64
+		wantFmin   float64
+		wantFEvals int
+		wantReason pkg.StopReason
 	}{
 		{
 			name: "f(x) = x^2 + 2x + 1 (minimum at x = -1)",
@@ -29,22 +33,29 @@ func TestTangentSearch(t *testing.T) {
 				b:   2.0,
 				eps: 1e-6,
 			},
-			wantXmin:  -1.0,
-			wantFmin:  0.0,
-			wantIters: 43,
+			wantXmin:   -1.0,
+			wantFmin:   0.0,
+			wantFEvals: 43,
+			wantReason: pkg.StopGradient,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotXmin, gotFmin, gotIters := TangentSearch(tt.args.f, tt.args.df, tt.args.a, tt.args.b, tt.args.eps)
-			if math.Abs(gotXmin-tt.wantXmin) > 1e-6 {
-				t.Errorf("TangentSearch() gotXmin = %v, want %v", gotXmin, tt.wantXmin)
+			res, err := TangentSearch(tt.args.f, tt.args.df, tt.args.a, tt.args.b, tt.args.eps, DefaultMaxIter, nil)
+			if err != nil {
+				t.Fatalf("TangentSearch() error = %v", err)
+			}
+			if math.Abs(res.Xmin-tt.wantXmin) > 1e-6 {
+				t.Errorf("TangentSearch() Xmin = %v, want %v", res.Xmin, tt.wantXmin)
 			}
-			if math.Abs(gotFmin-tt.wantFmin) > 1e-6 {
-				t.Errorf("TangentSearch() gotFmin = %v, want %v", gotFmin, tt.wantFmin)
+			if math.Abs(res.Fmin-tt.wantFmin) > 1e-6 {
+				t.Errorf("TangentSearch() Fmin = %v, want %v", res.Fmin, tt.wantFmin)
 			}
-			if gotIters != tt.wantIters {
-				t.Errorf("TangentSearch() gotIters = %v, want %v", gotIters, tt.wantIters)
+			if res.FEvals != tt.wantFEvals {
+				t.Errorf("TangentSearch() FEvals = %v, want %v", res.FEvals, tt.wantFEvals)
+			}
+			if res.Reason != tt.wantReason {
+				t.Errorf("TangentSearch() Reason = %v, want %v", res.Reason, tt.wantReason)
 			}
 		})
 	}
@@ -59,11 +70,12 @@ func TestNewtonSearch(t *testing.T) {
 		eps float64
 	}
 	tests := []struct {
-		name      string
-		args      args
-		wantXmin  float64
-		wantFmin  float64
-		wantIters int
+		name       string
+		args       args
+		wantXmin   float64
+		wantFmin   float64
+		wantFEvals int
+		wantReason pkg.StopReason
 	}{
 		{
 			name: "Case 1: f(x) = x + 2/x",
@@ -74,22 +86,29 @@ func TestNewtonSearch(t *testing.T) {
 				x0:  0.5,
 				eps: 0.5,
 			},
-			wantXmin:  1.239,
-			wantFmin:  2.853,
-			wantIters: 1,
+			wantXmin:   1.239,
+			wantFmin:   2.853,
+			wantFEvals: 1,
+			wantReason: pkg.StopGradient,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotXmin, gotFmin, gotIters := NewtonSearch(tt.args.f, tt.args.df, tt.args.d2f, tt.args.x0, tt.args.eps)
-			if math.Abs(gotXmin-tt.wantXmin) > 1e-3 {
-				t.Errorf("NewtonSearch() gotXmin = %v, want %v", gotXmin, tt.wantXmin)
+			res, err := NewtonSearch(tt.args.f, tt.args.df, tt.args.d2f, tt.args.x0, tt.args.eps, DefaultMaxIter, nil)
+			if err != nil {
+				t.Fatalf("NewtonSearch() error = %v", err)
 			}
-			if math.Abs(gotFmin-tt.wantFmin) > 1e-3 {
-				t.Errorf("NewtonSearch() gotFmin = %v, want %v", gotFmin, tt.wantFmin)
+			if math.Abs(res.Xmin-tt.wantXmin) > 1e-3 {
+				t.Errorf("NewtonSearch() Xmin = %v, want %v", res.Xmin, tt.wantXmin)
 			}
-			if gotIters != tt.wantIters {
-				t.Errorf("NewtonSearch() gotIters = %v, want %v", gotIters, tt.wantIters)
+			if math.Abs(res.Fmin-tt.wantFmin) > 1e-3 {
+				t.Errorf("NewtonSearch() Fmin = %v, want %v", res.Fmin, tt.wantFmin)
+			}
+			if res.FEvals != tt.wantFEvals {
+				t.Errorf("NewtonSearch() FEvals = %v, want %v", res.FEvals, tt.wantFEvals)
+			}
+			if res.Reason != tt.wantReason {
+				t.Errorf("NewtonSearch() Reason = %v, want %v", res.Reason, tt.wantReason)
 			}
 		})
 	}
@@ -104,11 +123,12 @@ func TestSecantSearch(t *testing.T) {
 		eps float64
 	}
 	tests := []struct {
-		name      string
-		args      args
-		wantXmin  float64
-		wantFmin  float64
-		wantIters int
+		name       string
+		args       args
+		wantXmin   float64
+		wantFmin   float64
+		wantFEvals int
+		wantReason pkg.StopReason
 	}{
 		{
 			name: "Case 1: f(x) = (x-2)^2",
@@ -119,22 +139,591 @@ func TestSecantSearch(t *testing.T) {
 				x1:  5.0,
 				eps: 1e-6,
 			},
-			wantXmin:  2.0,
-			wantFmin:  0,
-			wantIters: 1,
+			wantXmin:   2.0,
+			wantFmin:   0,
+			wantFEvals: 1,
+			wantReason: pkg.StopGradient,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := SecantSearch(tt.args.f, tt.args.df, tt.args.x0, tt.args.x1, tt.args.eps, DefaultMaxIter, nil)
+			if err != nil {
+				t.Fatalf("SecantSearch() error = %v", err)
+			}
+			if math.Abs(res.Xmin-tt.wantXmin) > 1e-3 {
+				t.Errorf("SecantSearch() Xmin = %v, want %v", res.Xmin, tt.wantXmin)
+			}
+			if math.Abs(res.Fmin-tt.wantFmin) > 1e-3 {
+				t.Errorf("SecantSearch() Fmin = %v, want %v", res.Fmin, tt.wantFmin)
+			}
+			if res.FEvals != tt.wantFEvals {
+				t.Errorf("SecantSearch() FEvals = %v, want %v", res.FEvals, tt.wantFEvals)
+			}
+			if res.Reason != tt.wantReason {
+				t.Errorf("SecantSearch() Reason = %v, want %v", res.Reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestSearchErrors(t *testing.T) {
+	// f(x) = x⁴/4 - x² + 2x: из x0 = 0 итерации Ньютона зацикливаются 0 → 1 → 0
+	cycleDF := func(x float64) float64 { return x*x*x - 2*x + 2 }
+	cycleD2F := func(x float64) float64 { return 3*x*x - 2 }
+	cycle := func(x float64) float64 { return x*x*x*x/4 - x*x + 2*x }
+	cubic := func(x float64) float64 { return x*x*x/3 - x }
+	cubicDF := func(x float64) float64 { return x*x - 1 }
+	// f(x) = √(1 + x²): f” убывает как |x|⁻³, и шаг Чебышёва из x0 = 1.5 уходит на бесконечность
+	hyp := func(x float64) float64 { return math.Sqrt(1 + x*x) }
+	hypDF := func(x float64) float64 { return x / math.Sqrt(1+x*x) }
+	hypD2F := func(x float64) float64 { return math.Pow(1+x*x, -1.5) }
+	hypD3F := func(x float64) float64 { return -3 * x * math.Pow(1+x*x, -2.5) }
+	tests := []struct {
+		name       string
+		run        func() (pkg.Result1D, error)
+		wantErr    error
+		wantReason pkg.StopReason
+	}{
+		{
+			name: "Newton: F1 from negative x0",
+			run: func() (pkg.Result1D, error) {
+				return NewtonSearch(pkg.F1, pkg.DF1, pkg.DDF1, -1, 1e-6, DefaultMaxIter, nil)
+			},
+			wantErr:    ErrDiverged,
+			wantReason: pkg.StopDiverged,
+		},
+		{
+			name: "Newton: 2-cycle",
+			run: func() (pkg.Result1D, error) {
+				return NewtonSearch(cycle, cycleDF, cycleD2F, 0, 1e-6, DefaultMaxIter, nil)
+			},
+			wantErr:    ErrMaxIter,
+			wantReason: pkg.StopMaxIter,
+		},
+		{
+			name: "Newton: f''(x0) = 0",
+			run: func() (pkg.Result1D, error) {
+				return NewtonSearch(cubic, cubicDF, func(x float64) float64 { return 2 * x }, 0, 1e-6, DefaultMaxIter, nil)
+			},
+			wantErr:    ErrZeroCurvature,
+			wantReason: pkg.StopZeroCurvature,
+		},
+		{
+			name: "Newton: step below ulp(x)",
+			run: func() (pkg.Result1D, error) {
+				return NewtonSearch(
+					func(x float64) float64 { return x },
+					func(x float64) float64 { return 1 },
+					func(x float64) float64 { return 1e300 },
+					1, 1e-6, DefaultMaxIter, nil,
+				)
+			},
+			wantErr:    ErrStagnation,
+			wantReason: pkg.StopStagnation,
+		},
+		{
+			name: "Secant: equal derivatives",
+			run: func() (pkg.Result1D, error) {
+				return SecantSearch(cubic, cubicDF, -2, 2, 1e-6, DefaultMaxIter, nil)
+			},
+			wantErr:    ErrZeroCurvature,
+			wantReason: pkg.StopZeroDenominator,
+		},
+		{
+			name: "Secant: x0 = x1",
+			run: func() (pkg.Result1D, error) {
+				return SecantSearch(pkg.F1, pkg.DF1, 2, 2, 1e-6, DefaultMaxIter, nil)
+			},
+			wantErr:    ErrStagnation,
+			wantReason: pkg.StopStagnation,
+		},
+		{
+			name: "Tangent: maximum inside [a, b]",
+			run: func() (pkg.Result1D, error) {
+				return TangentSearch(
+					func(x float64) float64 { return -x * x },
+					func(x float64) float64 { return -2 * x },
+					-1, 1, 1e-6, DefaultMaxIter, nil,
+				)
+			},
+			wantErr:    ErrNotBracketed,
+			wantReason: pkg.StopNotBracketed,
+		},
+		{
+			name: "Chebyshev: sqrt(1+x^2) from x0 = 1.5",
+			run: func() (pkg.Result1D, error) {
+				return ChebyshevSearch(hyp, hypDF, hypD2F, hypD3F, 1.5, 1e-6, DefaultMaxIter, nil)
+			},
+			wantErr:    ErrDiverged,
+			wantReason: pkg.StopDiverged,
+		},
+		{
+			name: "Chebyshev: f''(x0) = 0",
+			run: func() (pkg.Result1D, error) {
+				return ChebyshevSearch(cubic, cubicDF, func(x float64) float64 { return 2 * x }, func(x float64) float64 { return 2 }, 0, 1e-6, DefaultMaxIter, nil)
+			},
+			wantErr:    ErrZeroCurvature,
+			wantReason: pkg.StopZeroCurvature,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := tt.run()
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if res.Reason != tt.wantReason {
+				t.Errorf("Reason = %v, want %v", res.Reason, tt.wantReason)
+			}
+			if res.Iters > DefaultMaxIter {
+				t.Errorf("Iters = %v, want at most %v", res.Iters, DefaultMaxIter)
+			}
+			if math.IsNaN(res.Xmin) || math.IsInf(res.Xmin, 0) {
+				t.Errorf("Xmin = %v, want the last finite iterate", res.Xmin)
+			}
+		})
+	}
+}
+
+func TestMaxIter(t *testing.T) {
+	// 2-цикл метода Ньютона 0 → 1 → 0 (см. TestSearchErrors)
+	cycleDF := func(x float64) float64 { return x*x*x - 2*x + 2 }
+	cycleD2F := func(x float64) float64 { return 3*x*x - 2 }
+	cycle := func(x float64) float64 { return x*x*x*x/4 - x*x + 2*x }
+	tests := []struct {
+		name      string
+		maxIter   int
+		wantIters int
+	}{
+		{name: "explicit limit", maxIter: 10, wantIters: 10},
+		{name: "zero means default", maxIter: 0, wantIters: DefaultMaxIter},
+		{name: "negative means default", maxIter: -1, wantIters: DefaultMaxIter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := NewtonSearch(cycle, cycleDF, cycleD2F, 0, 1e-6, tt.maxIter, nil)
+			if !errors.Is(err, ErrMaxIter) {
+				t.Errorf("error = %v, want %v", err, ErrMaxIter)
+			}
+			if res.Iters != tt.wantIters {
+				t.Errorf("Iters = %v, want %v", res.Iters, tt.wantIters)
+			}
+		})
+	}
+}
+
+func TestConvergenceOrder(t *testing.T) {
+	xstar := math.Cbrt(2)
+	tests := []struct {
+		name      string
+		run       func(tr *pkg.Trace) (pkg.Result1D, error)
+		wantOrder float64
+		wantRate  float64
+		// число записанных приближений сверх числа итераций
+		wantExtra int
+	}{
+		{
+			// C = |f'''(x*) / (2f''(x*))| = 2 / x*
+			name: "Newton",
+			run: func(tr *pkg.Trace) (pkg.Result1D, error) {
+				return NewtonSearch(pkg.F1, pkg.DF1, pkg.DDF1, 0.5, 1e-12, DefaultMaxIter, tr)
+			},
+			wantOrder: 2,
+			wantRate:  2 / xstar,
+			wantExtra: 1,
+		},
+		{
+			// C = |f'''(x*) / (2f''(x*))|^(p-1), p = (1 + √5) / 2
+			name: "Secant",
+			run: func(tr *pkg.Trace) (pkg.Result1D, error) {
+				return SecantSearch(pkg.F1, pkg.DF1, 1, 2, 1e-12, DefaultMaxIter, tr)
+			},
+			wantOrder: (1 + math.Sqrt(5)) / 2,
+			wantRate:  math.Pow(2/xstar, (math.Sqrt(5)-1)/2),
+			wantExtra: 2,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotXmin, gotFmin, gotIters := SecantSearch(tt.args.f, tt.args.df, tt.args.x0, tt.args.x1, tt.args.eps)
-			if math.Abs(gotXmin-tt.wantXmin) > 1e-3 {
-				t.Errorf("SecantSearch() gotXmin = %v, want %v", gotXmin, tt.wantXmin)
+			var tr pkg.Trace
+			res, err := tt.run(&tr)
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if len(tr) != res.Iters+tt.wantExtra {
+				t.Errorf("len(Trace) = %v, want Iters + %v = %v", len(tr), tt.wantExtra, res.Iters+tt.wantExtra)
+			}
+			order, rate, err := pkg.EstimateConvergence(tr.Errors(xstar))
+			if err != nil {
+				t.Fatalf("EstimateConvergence() error = %v, trace %v", err, tr)
+			}
+			if math.Abs(order-tt.wantOrder) > 0.05 {
+				t.Errorf("order = %v, want %v", order, tt.wantOrder)
+			}
+			if math.Abs(rate-tt.wantRate) > 0.1*tt.wantRate {
+				t.Errorf("rate = %v, want %v", rate, tt.wantRate)
+			}
+		})
+	}
+}
+
+func TestTangentTrace(t *testing.T) {
+	// в трассу попадают только точки пересечения касательных, но не концы отрезка
+	var tr pkg.Trace
+	res, err := TangentSearch(pkg.F1, pkg.DF1, 0.5, 5, 1e-6, DefaultMaxIter, &tr)
+	if err != nil {
+		t.Fatalf("TangentSearch() error = %v", err)
+	}
+	if len(tr) != res.Iters {
+		t.Errorf("len(Trace) = %v, want Iters = %v", len(tr), res.Iters)
+	}
+	for _, x := range tr {
+		if x[0] == 0.5 || x[0] == 5 {
+			t.Errorf("Trace %v contains an endpoint of [0.5, 5]", tr)
+			break
+		}
+	}
+	if last := tr[len(tr)-1][0]; last != res.Xmin {
+		t.Errorf("last traced point = %v, want Xmin = %v", last, res.Xmin)
+	}
+}
+
+func TestSafeguardedNewtonSearch(t *testing.T) {
+	type args struct {
+		f       func(x float64) float64
+		df      func(x float64) float64
+		d2f     func(x float64) float64
+		a       float64
+		b       float64
+		eps     float64
+		maxIter int
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantXmin   float64
+		wantFmin   float64
+		wantIters  int
+		wantReason pkg.StopReason
+		wantErr    error
+	}{
+		{
+			name: "Case 1: f(x) = x + 1/x^2",
+			args: args{
+				f:   pkg.F1,
+				df:  pkg.DF1,
+				d2f: pkg.DDF1,
+				a:   0.5,
+				b:   5,
+				eps: 1e-8,
+			},
+			wantXmin:   math.Cbrt(2),
+			wantFmin:   1.5 * math.Cbrt(2),
+			wantIters:  6,
+			wantReason: pkg.StopGradient,
+		},
+		{
+			name: "Case 2: f(x) = x^4 - 3x^2 (f'' < 0 near the midpoint)",
+			args: args{
+				f:   func(x float64) float64 { return x*x*x*x - 3*x*x },
+				df:  func(x float64) float64 { return 4*x*x*x - 6*x },
+				d2f: func(x float64) float64 { return 12*x*x - 6 },
+				a:   0.05,
+				b:   1.3,
+				eps: 1e-8,
+			},
+			wantXmin:   math.Sqrt(1.5),
+			wantFmin:   -2.25,
+			wantIters:  6,
+			wantReason: pkg.StopGradient,
+		},
+		{
+			name: "Case 3: minimum at the left end",
+			args: args{
+				f:   pkg.F1,
+				df:  pkg.DF1,
+				d2f: pkg.DDF1,
+				a:   2,
+				b:   5,
+				eps: 1e-8,
+			},
+			wantXmin:   2,
+			wantFmin:   2.25,
+			wantIters:  0,
+			wantReason: pkg.StopBoundary,
+		},
+		{
+			// d2f < 0 везде: каждый шаг Ньютона отвергается, и отрезок лишь делится пополам
+			name: "Case 4: Newton steps are always rejected",
+			args: args{
+				f:       func(x float64) float64 { return x * x },
+				df:      func(x float64) float64 { return 2 * x },
+				d2f:     func(x float64) float64 { return -2 },
+				a:       -1,
+				b:       2.5,
+				eps:     1e-12,
+				maxIter: 5,
+			},
+			wantXmin:   0.0390625,
+			wantFmin:   0.0390625 * 0.0390625,
+			wantIters:  5,
+			wantReason: pkg.StopMaxIter,
+			wantErr:    ErrMaxIter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := SafeguardedNewtonSearch(tt.args.f, tt.args.df, tt.args.d2f, tt.args.a, tt.args.b, tt.args.eps, tt.args.maxIter)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SafeguardedNewtonSearch() error = %v, want %v", err, tt.wantErr)
+			}
+			if math.Abs(res.Xmin-tt.wantXmin) > 1e-6 {
+				t.Errorf("SafeguardedNewtonSearch() Xmin = %v, want %v", res.Xmin, tt.wantXmin)
+			}
+			if math.Abs(res.Fmin-tt.wantFmin) > 1e-6 {
+				t.Errorf("SafeguardedNewtonSearch() Fmin = %v, want %v", res.Fmin, tt.wantFmin)
+			}
+			if res.Iters != tt.wantIters {
+				t.Errorf("SafeguardedNewtonSearch() Iters = %v, want %v", res.Iters, tt.wantIters)
+			}
+			if res.Reason != tt.wantReason {
+				t.Errorf("SafeguardedNewtonSearch() Reason = %v, want %v", res.Reason, tt.wantReason)
+			}
+			if res.Xmin < tt.args.a || res.Xmin > tt.args.b {
+				t.Errorf("SafeguardedNewtonSearch() Xmin = %v outside [%v, %v]", res.Xmin, tt.args.a, tt.args.b)
+			}
+		})
+	}
+}
+
+func TestThirdOrderSearch(t *testing.T) {
+	type args struct {
+		f   func(x float64) float64
+		df  func(x float64) float64
+		d2f func(x float64) float64
+		d3f func(x float64) float64
+		x0  float64
+		eps float64
+	}
+	f := args{
+		f:   func(x float64) float64 { return x + 2/x },
+		df:  func(x float64) float64 { return 1 - 2/(x*x) },
+		d2f: func(x float64) float64 { return 4 / (x * x * x) },
+		d3f: func(x float64) float64 { return -12 / (x * x * x * x) },
+		eps: 1e-12,
+	}
+	methods := map[string]func(f, df, d2f, d3f func(x float64) float64, x0, eps float64, maxIter int, tr *pkg.Trace) (pkg.Result1D, error){
+		"HalleySearch":    HalleySearch,
+		"ChebyshevSearch": ChebyshevSearch,
+	}
+	tests := []struct {
+		name      string
+		method    string
+		x0        float64
+		wantIters int
+	}{
+		{name: "Halley, x0 = 0.5", method: "HalleySearch", x0: 0.5, wantIters: 4},
+		{name: "Halley, x0 = 1", method: "HalleySearch", x0: 1, wantIters: 3},
+		{name: "Chebyshev, x0 = 0.5", method: "ChebyshevSearch", x0: 0.5, wantIters: 5},
+		{name: "Chebyshev, x0 = 1", method: "ChebyshevSearch", x0: 1, wantIters: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := methods[tt.method](f.f, f.df, f.d2f, f.d3f, tt.x0, f.eps, DefaultMaxIter, nil)
+			if err != nil {
+				t.Fatalf("%s() error = %v", tt.method, err)
+			}
+			if math.Abs(res.Xmin-math.Sqrt2) > 1e-9 {
+				t.Errorf("%s() Xmin = %v, want %v", tt.method, res.Xmin, math.Sqrt2)
+			}
+			if math.Abs(res.Fmin-2*math.Sqrt2) > 1e-9 {
+				t.Errorf("%s() Fmin = %v, want %v", tt.method, res.Fmin, 2*math.Sqrt2)
+			}
+			if res.Iters != tt.wantIters {
+				t.Errorf("%s() Iters = %v, want %v", tt.method, res.Iters, tt.wantIters)
+			}
+			if res.Reason != pkg.StopGradient {
+				t.Errorf("%s() Reason = %v, want %v", tt.method, res.Reason, pkg.StopGradient)
+			}
+			if res.D2FEvals != res.Iters || res.D3FEvals != res.Iters {
+				t.Errorf("%s() D2FEvals, D3FEvals = %v, %v, want %v each", tt.method, res.D2FEvals, res.D3FEvals, res.Iters)
+			}
+			// метод третьего порядка не медленнее метода Ньютона
+			if newton, _ := NewtonSearch(f.f, f.df, f.d2f, tt.x0, f.eps, DefaultMaxIter, nil); res.Iters > newton.Iters {
+				t.Errorf("%s() Iters = %v, Newton needs only %v", tt.method, res.Iters, newton.Iters)
+			}
+		})
+	}
+}
+
+func TestFalsePositionSearch(t *testing.T) {
+	type args struct {
+		f       func(x float64) float64
+		df      func(x float64) float64
+		a       float64
+		b       float64
+		eps     float64
+		maxIter int
+	}
+	f1 := args{f: pkg.F1, df: pkg.DF1, a: 0.5, b: 5, eps: 1e-10}
+	f2 := args{
+		f:   func(x float64) float64 { return x*x*x*x/4 + x },
+		df:  func(x float64) float64 { return x*x*x + 1 },
+		a:   -3,
+		b:   5,
+		eps: 1e-10,
+	}
+	methods := map[string]func(f, df func(x float64) float64, a, b, eps float64, maxIter int) (pkg.Result1D, error){
+		"RegulaFalsiSearch":    RegulaFalsiSearch,
+		"IllinoisSearch":       IllinoisSearch,
+		"AndersonBjorckSearch": AndersonBjorckSearch,
+	}
+	tests := []struct {
+		name       string
+		method     string
+		args       args
+		wantXmin   float64
+		wantIters  int
+		wantReason pkg.StopReason
+		wantErr    error
+	}{
+		{name: "regula falsi, F1", method: "RegulaFalsiSearch", args: f1, wantXmin: math.Cbrt(2), wantIters: 205, wantReason: pkg.StopGradient},
+		{name: "Illinois, F1", method: "IllinoisSearch", args: f1, wantXmin: math.Cbrt(2), wantIters: 14, wantReason: pkg.StopGradient},
+		{name: "Anderson–Björck, F1", method: "AndersonBjorckSearch", args: f1, wantXmin: math.Cbrt(2), wantIters: 14, wantReason: pkg.StopGradient},
+		{name: "regula falsi, x^4/4 + x", method: "RegulaFalsiSearch", args: f2, wantXmin: -1, wantIters: 151, wantReason: pkg.StopGradient},
+		{name: "Illinois, x^4/4 + x", method: "IllinoisSearch", args: f2, wantXmin: -1, wantIters: 11, wantReason: pkg.StopGradient},
+		{name: "Anderson–Björck, x^4/4 + x", method: "AndersonBjorckSearch", args: f2, wantXmin: -1, wantIters: 9, wantReason: pkg.StopGradient},
+		{
+			name:       "Illinois, minimum at the right end",
+			method:     "IllinoisSearch",
+			args:       args{f: pkg.F1, df: pkg.DF1, a: 0.5, b: 1, eps: 1e-10},
+			wantXmin:   1,
+			wantIters:  0,
+			wantReason: pkg.StopBoundary,
+		},
+		{
+			// один конец неподвижен: без ограничения понадобилось бы 205 итераций
+			name:       "regula falsi, F1, maxIter = 50",
+			method:     "RegulaFalsiSearch",
+			args:       args{f: pkg.F1, df: pkg.DF1, a: 0.5, b: 5, eps: 1e-10, maxIter: 50},
+			wantIters:  50,
+			wantReason: pkg.StopMaxIter,
+			wantErr:    ErrMaxIter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := methods[tt.method](tt.args.f, tt.args.df, tt.args.a, tt.args.b, tt.args.eps, tt.args.maxIter)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("%s() error = %v, want %v", tt.method, err, tt.wantErr)
+			}
+			if tt.wantErr == nil && math.Abs(res.Xmin-tt.wantXmin) > 1e-9 {
+				t.Errorf("%s() Xmin = %v, want %v", tt.method, res.Xmin, tt.wantXmin)
+			}
+			if res.Fmin != tt.args.f(res.Xmin) {
+				t.Errorf("%s() Fmin = %v, want f(Xmin) = %v", tt.method, res.Fmin, tt.args.f(res.Xmin))
+			}
+			if res.Iters != tt.wantIters {
+				t.Errorf("%s() Iters = %v, want %v", tt.method, res.Iters, tt.wantIters)
+			}
+			if res.Reason != tt.wantReason {
+				t.Errorf("%s() Reason = %v, want %v", tt.method, res.Reason, tt.wantReason)
+			}
+			if res.AFinal > res.Xmin || res.Xmin > res.BFinal {
+				t.Errorf("%s() Xmin = %v outside [%v, %v]", tt.method, res.Xmin, res.AFinal, res.BFinal)
+			}
+		})
+	}
+}
+
+func TestCubicSearch(t *testing.T) {
+	type args struct {
+		f   func(x float64) float64
+		df  func(x float64) float64
+		a   float64
+		b   float64
+		eps float64
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantXmin   float64
+		wantIters  int
+		wantReason pkg.StopReason
+	}{
+		{
+			name:       "Case 1: f(x) = x + 1/x^2",
+			args:       args{f: pkg.F1, df: pkg.DF1, a: 0.5, b: 5, eps: 1e-10},
+			wantXmin:   math.Cbrt(2),
+			wantIters:  6,
+			wantReason: pkg.StopGradient,
+		},
+		{
+			name:       "Case 2: f(x) = sin(x)",
+			args:       args{f: math.Sin, df: math.Cos, a: 2, b: 6, eps: 1e-10},
+			wantXmin:   3 * math.Pi / 2,
+			wantIters:  5,
+			wantReason: pkg.StopGradient,
+		},
+		{
+			name: "Case 3: f(x) = (x-2)^2 (one iteration)",
+			args: args{
+				f:   func(x float64) float64 { return (x - 2) * (x - 2) },
+				df:  func(x float64) float64 { return 2 * (x - 2) },
+				a:   0,
+				b:   5,
+				eps: 1e-10,
+			},
+			wantXmin:   2,
+			wantIters:  1,
+			wantReason: pkg.StopGradient,
+		},
+		{
+			name: "Case 4: f(x) = |x-1| (non-smooth, bracket shrinks)",
+			args: args{
+				f:  func(x float64) float64 { return math.Abs(x - 1) },
+				df: func(x float64) float64 { return math.Copysign(1, x-1) },
+				a:  0,
+				b:  5,
+				// |f'| = 1 всюду, остановка только по длине отрезка
+				eps: 1e-10,
+			},
+			wantXmin:   1,
+			wantIters:  20,
+			wantReason: pkg.StopInterval,
+		},
+		{
+			name: "Case 5: f(x) = sin(3x) (not unimodal, f(x) ≥ f(a) while f'(x) < 0)",
+			args: args{
+				f:   func(x float64) float64 { return math.Sin(3 * x) },
+				df:  func(x float64) float64 { return 3 * math.Cos(3*x) },
+				a:   1,
+				b:   4,
+				eps: 1e-10,
+			},
+			wantXmin:   7 * math.Pi / 6,
+			wantIters:  6,
+			wantReason: pkg.StopGradient,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := CubicSearch(tt.args.f, tt.args.df, tt.args.a, tt.args.b, tt.args.eps)
+			if math.Abs(res.Xmin-tt.wantXmin) > 1e-9 {
+				t.Errorf("CubicSearch() Xmin = %v, want %v", res.Xmin, tt.wantXmin)
+			}
+			if res.Fmin != tt.args.f(res.Xmin) {
+				t.Errorf("CubicSearch() Fmin = %v, want f(Xmin) = %v", res.Fmin, tt.args.f(res.Xmin))
+			}
+			if res.Iters != tt.wantIters {
+				t.Errorf("CubicSearch() Iters = %v, want %v", res.Iters, tt.wantIters)
 			}
-			if math.Abs(gotFmin-tt.wantFmin) > 1e-3 {
-				t.Errorf("SecantSearch() gotFmin = %v, want %v", gotFmin, tt.wantFmin)
+			if res.Reason != tt.wantReason {
+				t.Errorf("CubicSearch() Reason = %v, want %v", res.Reason, tt.wantReason)
 			}
-			if gotIters != tt.wantIters {
-				t.Errorf("SecantSearch() gotIters = %v, want %v", gotIters, tt.wantIters)
+			if !(tt.args.df(res.AFinal) < 0 && tt.args.df(res.BFinal) > 0) {
+				t.Errorf("CubicSearch() final bracket [%v, %v] lost f'(a) < 0 < f'(b)", res.AFinal, res.BFinal)
 			}
 		})
 	}
@@ -0,0 +1,51 @@
+package highordered
+
+import (
+	"errors"
+	"math"
+)
+
+// Ошибки методов, которые не могут гарантировать сходимость (TangentSearch, NewtonSearch, SecantSearch,
+// HalleySearch, ChebyshevSearch) или ограничены числом итераций (SafeguardedNewtonSearch,
+// RegulaFalsiSearch, IllinoisSearch, AndersonBjorckSearch).
+// Вместе с ошибкой возвращается последнее конечное приближение.
+var (
+	// ErrMaxIter — за maxIter итераций условие остановы не выполнено.
+	ErrMaxIter = errors.New("maximum number of iterations exceeded")
+	// ErrDiverged — очередное приближение не является конечным числом или ушло слишком далеко
+	// от начального (см. diverged).
+	ErrDiverged = errors.New("iterates diverged")
+	// ErrZeroCurvature — знаменатель шага (f” или её разностная оценка) обратился в нуль.
+	ErrZeroCurvature = errors.New("zero curvature")
+	// ErrNotBracketed — отрезок не локализует минимум или приближение вышло за его пределы.
+	ErrNotBracketed = errors.New("minimum is not bracketed")
+	// ErrStagnation — приближение перестало изменяться, хотя |f'(x)| > eps.
+	ErrStagnation = errors.New("iterates stagnated")
+)
+
+// DefaultMaxIter — наибольшее число итераций методов, возвращающих ErrMaxIter,
+// если параметр maxIter не задан (maxIter ≤ 0).
+const DefaultMaxIter = 1000
+
+// divergeScale — во сколько раз приближение может превзойти масштаб начальной точки:
+// при большем |x| начальная точка меньше ulp(x), и итерации уже не отличить от ухода на бесконечность.
+const divergeScale = 1 << 52
+
+// finite сообщает, является ли x конечным числом.
+func finite(x float64) bool {
+	return !math.IsNaN(x) && !math.IsInf(x, 0)
+}
+
+// diverged сообщает, что приближение x не конечно или |x| > divergeScale·max(scale, 1),
+// где scale — модуль начальной точки.
+func diverged(x, scale float64) bool {
+	return !finite(x) || math.Abs(x) > divergeScale*max(scale, 1)
+}
+
+// iterLimit возвращает maxIter или DefaultMaxIter, если maxIter ≤ 0.
+func iterLimit(maxIter int) int {
+	if maxIter <= 0 {
+		return DefaultMaxIter
+	}
+	return maxIter
+}
@@ -1,14 +1,23 @@
+// Package multidimensional реализует методы минимизации функций многих переменных.
+//
+// Методы для функции двух переменных f(x, y) — обёртки над одноимёнными методами с суффиксом N,
+// которые принимают функцию вектора x = (x_1, ..., x_n). Срезы, которые методы N передают в f,
+// grad и hess, переиспользуются между вызовами: сохранять их нельзя, а изменять можно только
+// g и h, куда записывается результат.
 package multidimensional
 
 import (
+	"cmp"
+	"errors"
+	"fmt"
 	"math"
+	"slices"
 
-	zeroordered "github.com/vshulcz/edu_optimization_methods/internal/1_zero_ordered"
 	"github.com/vshulcz/edu_optimization_methods/pkg"
 )
 
 // CoordinateDescent реализует метод покоординатного спуска для минимизации функции двух переменных f(x, y)
-// с использованием одномерного метода (например, золотого сечения) на каждом шаге.
+// с использованием одномерного метода line (например, zeroordered.GoldenSection) на каждом шаге.
 //
 // Алгоритм:
 // - Начинается с точки (x0, y0).
@@ -27,33 +36,55 @@ import (
 func CoordinateDescent(
 	f func(x, y float64) float64,
 	x0, y0, ax, bx, ay, by, eps float64,
+	line pkg.LineMinimizer,
 ) (xmin, ymin, fmin float64, iters int) {
-	x, y := x0, y0
+	x, fmin, iters := CoordinateDescentN(func2(f), []float64{x0, y0}, []float64{ax, ay}, []float64{bx, by}, eps, line)
+	return x[0], x[1], fmin, iters
+}
 
-	phiF := func(x_, y_ float64) float64 {
+// CoordinateDescentN — покоординатный спуск для функции n переменных f(x), x = (x_1, ..., x_n).
+// Алгоритм — как у CoordinateDescent: координаты минимизируются по очереди методом line
+// на отрезках [lo[i], hi[i]], пока смещение точки или изменение f не станет не больше eps.
+//
+// Возвращает точку минимума xmin, значение функции в ней fmin и общее число вызовов f (iters).
+func CoordinateDescentN(
+	f func(x []float64) float64,
+	x0, lo, hi []float64,
+	eps float64,
+	line pkg.LineMinimizer,
+) (xmin []float64, fmin float64, iters int) {
+	n := len(x0)
+	x := slices.Clone(x0)
+	t := make([]float64, n)
+
+	phiF := func(x_ []float64) float64 {
 		iters++
-		return f(x_, y_)
+		return f(x_)
 	}
 
-	prevX, prevY := x, y
-	prevF := phiF(x, y)
+	prev := slices.Clone(x)
+	prevF := phiF(x)
 
 	for {
-		gx := func(xx float64) float64 { return phiF(xx, y) }
-		x, _, _ = zeroordered.GoldenSectionSearch(gx, ax, bx, eps)
-
-		gy := func(yy float64) float64 { return phiF(x, yy) }
-		y, _, _ = zeroordered.GoldenSectionSearch(gy, ay, by, eps)
+		for i := range n {
+			gi := func(xi float64) float64 {
+				copy(t, x)
+				t[i] = xi
+				return phiF(t)
+			}
+			x[i] = line.Minimize(gi, lo[i], hi[i], eps).Xmin
+		}
 
-		currF := phiF(x, y)
-		if math.Hypot(x-prevX, y-prevY) <= eps || math.Abs(currF-prevF) <= eps {
+		currF := phiF(x)
+		if dist(x, prev) <= eps || math.Abs(currF-prevF) <= eps {
 			break
 		}
 
-		prevX, prevY, prevF = x, y, currF
+		copy(prev, x)
+		prevF = currF
 	}
 
-	return x, y, phiF(x, y), iters
+	return x, phiF(x), iters
 }
 
 // GradientDescentBacktracking реализует градиентный метод минимизации функции f(x, y)
@@ -90,36 +121,56 @@ func GradientDescentBacktracking(
 	grad func(x, y float64) (gx, gy float64),
 	x0, y0, alphaHat, epsilon, lambda, deltaGrad float64,
 ) (xmin, ymin, fmin float64, iters int) {
-	x, y := x0, y0
+	x, fmin, iters := GradientDescentBacktrackingN(func2(f), grad2(grad), []float64{x0, y0}, alphaHat, epsilon, lambda, deltaGrad)
+	return x[0], x[1], fmin, iters
+}
 
-	phiF := func(x_, y_ float64) float64 {
+// GradientDescentBacktrackingN — градиентный метод с дроблением шага для функции n переменных.
+// Алгоритм и параметры — как у GradientDescentBacktracking; grad записывает ∇f(x) в g.
+//
+// Возвращает точку минимума xmin, значение функции в ней fmin и количество вызовов f (iters).
+func GradientDescentBacktrackingN(
+	f func(x []float64) float64,
+	grad func(x, g []float64),
+	x0 []float64,
+	alphaHat, epsilon, lambda, deltaGrad float64,
+) (xmin []float64, fmin float64, iters int) {
+	n := len(x0)
+	x := slices.Clone(x0)
+	g := make([]float64, n)
+	xNew := make([]float64, n)
+
+	phiF := func(x_ []float64) float64 {
 		iters++
-		return f(x_, y_)
+		return f(x_)
 	}
 
 	for {
-		gx, gy := grad(x, y)
-		gradNorm2 := gx*gx + gy*gy
+		grad(x, g)
+		var gradNorm2 float64
+		for _, gi := range g {
+			gradNorm2 += gi * gi
+		}
 		if math.Sqrt(gradNorm2) < deltaGrad {
 			break
 		}
 
 		alpha := alphaHat
-		fx := phiF(x, y)
-		var xNew, yNew, fxNew float64
+		fx := phiF(x)
 		for {
-			xNew = x - alpha*gx
-			yNew = y - alpha*gy
-			fxNew = phiF(xNew, yNew)
+			for i := range x {
+				xNew[i] = x[i] - alpha*g[i]
+			}
+			fxNew := phiF(xNew)
 			if fxNew-fx <= -alpha*epsilon*gradNorm2 {
 				break
 			}
 			alpha *= lambda
 		}
-		x, y = xNew, yNew
+		copy(x, xNew)
 	}
 
-	return x, y, phiF(x, y), iters
+	return x, phiF(x), iters
 }
 
 // SteepestGradientDescent реализует метод наискорейшего градиентного спуска (МНГС)
@@ -131,49 +182,87 @@ func GradientDescentBacktracking(
 // То есть шаг α подбирается оптимально по направлению антиградиента.
 //
 // Алгоритм:
-// - Вычисляется градиент ∇f(x, y).
-// - Если ||∇f|| ≤ gradEps, выполнение прекращается (достигнута стационарная точка).
-// - Вдоль направления (-gx, -gy) строится функция φ(α) = f(x - αgx, y - αgy).
-// - Параметр α минимизируется методом золотого сечения (на предварительно подобранном отрезке).
-// - Точка обновляется: x ← x - α * gx, y ← y - α * gy.
+//   - Вычисляется градиент ∇f(x, y).
+//   - Если ||∇f|| ≤ gradEps, выполнение прекращается (достигнута стационарная точка).
+//   - Вдоль направления (-gx, -gy) строится функция φ(α) = f(x - αgx, y - αgy).
+//   - Параметр α минимизируется одномерным методом line (на отрезке, подобранном pkg.Bracket).
+//     Если φ постоянна с точностью до округления (pkg.ErrFlat), x считается стационарной точкой
+//     и спуск завершается; если минимум φ не локализуется иначе — спуск прекращается с ошибкой.
+//   - Точка обновляется: x ← x - α * gx, y ← y - α * gy.
 //
 // Особенности:
 // - Обеспечивает наискорейшее уменьшение функции на каждом шаге (локально оптимальный шаг).
 // - Шаг подбирается точно, а не приближённо (в отличие от градиентного метода с дроблением).
 // - При выпуклости функции спуск стабильно сходится к минимуму.
 // - Направления градиентов на соседних итерациях ортогональны (⟨∇f(x_k+1), ∇f(x_k)⟩ = 0).
+// - Если tr != nil, в трассу записывается каждое приближение (x_k, y_k), включая начальное и итоговое.
 //
 // Возвращает: координаты точки минимума (xmin, ymin), значение функции в ней (fmin),
-// и общее количество вызовов f (iters).
+// и общее количество вызовов f (iters). Если минимум φ не локализуется (функция не ограничена
+// снизу вдоль антиградиента), возвращается достигнутая точка и ошибка, оборачивающая
+// pkg.ErrNoBracket: такая точка не является решением.
 func SteepestGradientDescent(
 	f func(x, y float64) float64,
 	grad func(x, y float64) (gx, gy float64),
 	x0, y0, gradEps float64,
-) (xmin, ymin, fmin float64, iters int) {
-	x, y := x0, y0
+	line pkg.LineMinimizer,
+	tr *pkg.Trace,
+) (xmin, ymin, fmin float64, iters int, err error) {
+	x, fmin, iters, err := SteepestGradientDescentN(func2(f), grad2(grad), []float64{x0, y0}, gradEps, line, tr)
+	return x[0], x[1], fmin, iters, err
+}
 
-	phiF := func(x_, y_ float64) float64 {
+// SteepestGradientDescentN — метод наискорейшего градиентного спуска для функции n переменных.
+// Алгоритм и трасса tr — как у SteepestGradientDescent; grad записывает ∇f(x) в g.
+//
+// Возвращает точку минимума xmin, значение функции в ней fmin, общее количество вызовов f (iters)
+// и ошибку — как у SteepestGradientDescent.
+func SteepestGradientDescentN(
+	f func(x []float64) float64,
+	grad func(x, g []float64),
+	x0 []float64,
+	gradEps float64,
+	line pkg.LineMinimizer,
+	tr *pkg.Trace,
+) (xmin []float64, fmin float64, iters int, err error) {
+	n := len(x0)
+	x := slices.Clone(x0)
+	g := make([]float64, n)
+	t := make([]float64, n)
+
+	phiF := func(x_ []float64) float64 {
 		iters++
-		return f(x_, y_)
+		return f(x_)
 	}
 
 	for {
-		gx, gy := grad(x, y)
-		if math.Hypot(gx, gy) <= gradEps {
+		tr.Add(x...)
+		grad(x, g)
+		if norm(g) <= gradEps {
 			break
 		}
 
 		phi := func(alpha float64) float64 {
-			return phiF(x-alpha*gx, y-alpha*gy)
+			for i := range x {
+				t[i] = x[i] - alpha*g[i]
+			}
+			return phiF(t)
 		}
-		a, b := pkg.BracketMinimum(phi)
-		alpha, _, _ := zeroordered.GoldenSectionSearch(phi, a, b, gradEps)
+		a, _, b, err := pkg.Bracket(phi, 0, pkg.BracketStep, pkg.BracketGrow)
+		if errors.Is(err, pkg.ErrFlat) {
+			break
+		}
+		if err != nil {
+			return x, phiF(x), iters, fmt.Errorf("line search: %w", err)
+		}
+		alpha := line.Minimize(phi, a, b, gradEps).Xmin
 
-		x -= alpha * gx
-		y -= alpha * gy
+		for i := range x {
+			x[i] -= alpha * g[i]
+		}
 	}
 
-	return x, y, phiF(x, y), iters
+	return x, phiF(x), iters, nil
 }
 
 // AcceleratedGradientDescent реализует ускоренный градиентный метод p-го порядка
@@ -195,7 +284,8 @@ func SteepestGradientDescent(
 // - grad: функция, возвращающая градиент ∇f(x, y);
 // - x0, y0: начальная точка;
 // - p: число предварительных шагов наискорейшего спуска (рекомендуется p = dim);
-// - gradEps: критерий остановы по норме градиента.
+// - gradEps: критерий остановы по норме градиента;
+// - line: одномерный метод поиска шага (например, zeroordered.GoldenSection).
 //
 // Особенности:
 // - Улучшает направление поиска за счёт приближённого выравнивания по овражной геометрии.
@@ -203,50 +293,112 @@ func SteepestGradientDescent(
 // - Может достигать минимума быстрее, чем классический градиентный спуск.
 //
 // Возвращает: точку минимума (xmin, ymin), значение функции fmin и общее число вызовов функции (iters).
+// Если φ постоянна вдоль антиградиента на одном из p шагов (pkg.ErrFlat) или промежуточная точка
+// совпала с (x, y), спуск завершается в промежуточной точке. Если минимум не локализуется
+// на шаге спуска или на ускоряющем шаге, возвращается достигнутая точка и ошибка,
+// как у SteepestGradientDescent; постоянство f вдоль (dx, dy) ошибкой не считается.
 func AcceleratedGradientDescent(
 	f func(x, y float64) float64,
 	grad func(x, y float64) (gx, gy float64),
 	x0, y0 float64,
 	p int,
 	gradEps float64,
-) (xmin, ymin, fmin float64, iters int) {
-	x, y := x0, y0
+	line pkg.LineMinimizer,
+) (xmin, ymin, fmin float64, iters int, err error) {
+	x, fmin, iters, err := AcceleratedGradientDescentN(func2(f), grad2(grad), []float64{x0, y0}, p, gradEps, line)
+	return x[0], x[1], fmin, iters, err
+}
 
-	phiF := func(x_, y_ float64) float64 {
+// AcceleratedGradientDescentN — ускоренный градиентный метод p-го порядка для функции n переменных.
+// Алгоритм и параметры — как у AcceleratedGradientDescent (рекомендуется p = n); grad записывает ∇f(x) в g.
+//
+// Возвращает точку минимума xmin, значение функции в ней fmin, общее число вызовов функции (iters)
+// и ошибку — как у AcceleratedGradientDescent.
+func AcceleratedGradientDescentN(
+	f func(x []float64) float64,
+	grad func(x, g []float64),
+	x0 []float64,
+	p int,
+	gradEps float64,
+	line pkg.LineMinimizer,
+) (xmin []float64, fmin float64, iters int, err error) {
+	n := len(x0)
+	x := slices.Clone(x0)
+	g := make([]float64, n)
+	xs := make([]float64, n)
+	gs := make([]float64, n)
+	d := make([]float64, n)
+	t := make([]float64, n)
+
+	phiF := func(x_ []float64) float64 {
 		iters++
-		return f(x_, y_)
+		return f(x_)
 	}
 
 	for {
-		gx, gy := grad(x, y)
-		if math.Hypot(gx, gy) <= gradEps {
+		grad(x, g)
+		if norm(g) <= gradEps {
 			break
 		}
 
-		xs, ys := x, y
+		copy(xs, x)
+		var flat bool
 		for range p {
-			gxs, gys := grad(xs, ys)
+			grad(xs, gs)
+			// xs уже стационарна: вдоль антиградиента φ плоская
+			if norm(gs) <= gradEps {
+				break
+			}
 			phi1 := func(alpha float64) float64 {
-				return phiF(xs-alpha*gxs, ys-alpha*gys)
+				for i := range xs {
+					t[i] = xs[i] - alpha*gs[i]
+				}
+				return phiF(t)
+			}
+			a, _, b, err := pkg.Bracket(phi1, 0, pkg.BracketStep, pkg.BracketGrow)
+			if errors.Is(err, pkg.ErrFlat) {
+				flat = true
+				break
+			}
+			if err != nil {
+				return x, phiF(x), iters, fmt.Errorf("line search: %w", err)
+			}
+			alpha := line.Minimize(phi1, a, b, gradEps).Xmin
+			for i := range xs {
+				xs[i] -= alpha * gs[i]
 			}
-			a, b := pkg.BracketMinimum(phi1)
-			alpha, _, _ := zeroordered.GoldenSectionSearch(phi1, a, b, gradEps)
-			xs -= alpha * gxs
-			ys -= alpha * gys
 		}
 
-		dx, dy := xs-x, ys-y
+		for i := range d {
+			d[i] = xs[i] - x[i]
+		}
+		// спуск остановился в стационарной точке: ускоряющий шаг не сдвинет x
+		if flat || norm(d) == 0 {
+			copy(x, xs)
+			break
+		}
 		phi2 := func(alpha float64) float64 {
-			return phiF(x+alpha*dx, y+alpha*dy)
+			for i := range x {
+				t[i] = x[i] + alpha*d[i]
+			}
+			return phiF(t)
+		}
+		// если f вдоль d постоянна, остаёмся в xs
+		alpha := 1.0
+		a, _, b, err := pkg.Bracket(phi2, 0, pkg.BracketStep, pkg.BracketGrow)
+		if err != nil && !errors.Is(err, pkg.ErrFlat) {
+			return x, phiF(x), iters, fmt.Errorf("line search: %w", err)
+		}
+		if err == nil {
+			alpha = line.Minimize(phi2, a, b, gradEps).Xmin
 		}
-		a, b := pkg.BracketMinimum(phi2)
-		alpha, _, _ := zeroordered.GoldenSectionSearch(phi2, a, b, gradEps)
 
-		x += alpha * dx
-		y += alpha * dy
+		for i := range x {
+			x[i] += alpha * d[i]
+		}
 	}
 
-	return x, y, phiF(x, y), iters
+	return x, phiF(x), iters, nil
 }
 
 // RavineGradientDescent реализует овражный метод оптимизации для функции двух переменных f(x, y),
@@ -264,7 +416,8 @@ func AcceleratedGradientDescent(
 // - x0, y0: начальная точка;
 // - delta: смещение для второй стартовой точки x̃^k (обычно малое);
 // - p: число шагов градиентного спуска от x^k и x̃^k;
-// - gradEps: критерий остановы по норме градиента.
+// - gradEps: критерий остановы по норме градиента;
+// - line: одномерный метод поиска шага (например, zeroordered.GoldenSection).
 //
 // Особенности:
 //   - Эффективен при оптимизации овражных функций, где стандартный градиентный спуск
@@ -273,66 +426,130 @@ func AcceleratedGradientDescent(
 //   - Позволяет быстро сойтись вдоль направления минимального изменения.
 //
 // Возвращает: координаты минимума (xmin, ymin), значение функции в этой точке (fmin),
-// и общее число вызовов функции (iters).
+// и общее число вызовов функции (iters). Если не удался спуск из x^k, возвращается ошибка,
+// как у SteepestGradientDescent; неудачи спуска из x̃^k и овражного шага ошибкой не считаются.
+// Если φ постоянна на одном из шагов спуска из x^k (pkg.ErrFlat) или итерация не сдвинула x^k,
+// спуск завершается.
 func RavineGradientDescent(
 	f func(x, y float64) float64,
 	grad func(x, y float64) (gx, gy float64),
 	x0, y0, delta float64,
 	p int,
 	gradEps float64,
-) (xmin, ymin, fmin float64, iters int) {
-	x, y := x0, y0
+	line pkg.LineMinimizer,
+) (xmin, ymin, fmin float64, iters int, err error) {
+	x, fmin, iters, err := RavineGradientDescentN(func2(f), grad2(grad), []float64{x0, y0}, delta, p, gradEps, line)
+	return x[0], x[1], fmin, iters, err
+}
 
-	phiF := func(x_, y_ float64) float64 {
+// RavineGradientDescentN — овражный метод для функции n переменных.
+// Алгоритм и параметры — как у RavineGradientDescent: вторая стартовая точка x̃ = x + δ·(1, ..., 1);
+// grad записывает ∇f(x) в g.
+//
+// Возвращает точку минимума xmin, значение функции в ней fmin, общее число вызовов функции (iters)
+// и ошибку — как у RavineGradientDescent.
+func RavineGradientDescentN(
+	f func(x []float64) float64,
+	grad func(x, g []float64),
+	x0 []float64,
+	delta float64,
+	p int,
+	gradEps float64,
+	line pkg.LineMinimizer,
+) (xmin []float64, fmin float64, iters int, err error) {
+	n := len(x0)
+	x := slices.Clone(x0)
+	g := make([]float64, n)
+	xs := make([]float64, n)
+	xst := make([]float64, n)
+	gs := make([]float64, n)
+	d := make([]float64, n)
+	t := make([]float64, n)
+
+	phiF := func(x_ []float64) float64 {
 		iters++
-		return f(x_, y_)
+		return f(x_)
+	}
+
+	// descend выполняет до p шагов наискорейшего спуска из точки z (на месте),
+	// останавливаясь в стационарной точке; возвращает ошибку pkg.Bracket, если минимум вдоль антиградиента
+	// не локализуется, в том числе pkg.ErrFlat, если φ постоянна (z — стационарная точка).
+	descend := func(z []float64) error {
+		for range p {
+			grad(z, gs)
+			if norm(gs) <= gradEps {
+				return nil
+			}
+			phi := func(alpha float64) float64 {
+				for i := range z {
+					t[i] = z[i] - alpha*gs[i]
+				}
+				return phiF(t)
+			}
+			a, _, b, err := pkg.Bracket(phi, 0, pkg.BracketStep, pkg.BracketGrow)
+			if err != nil {
+				return err
+			}
+			alpha := line.Minimize(phi, a, b, gradEps).Xmin
+			for i := range z {
+				z[i] -= alpha * gs[i]
+			}
+		}
+		return nil
 	}
 
 	for {
-		gx, gy := grad(x, y)
-		if math.Hypot(gx, gy) <= gradEps {
+		grad(x, g)
+		if norm(g) <= gradEps {
 			break
 		}
 
-		xt, yt := x+delta, y+delta
-
-		xs, ys := x, y
-		for range p {
-			gxs, gys := grad(xs, ys)
-			phi := func(alpha float64) float64 {
-				return phiF(xs-alpha*gxs, ys-alpha*gys)
-			}
-			a, b := pkg.BracketMinimum(phi)
-			alpha, _, _ := zeroordered.GoldenSectionSearch(phi, a, b, gradEps)
-			xs -= alpha * gxs
-			ys -= alpha * gys
+		for i := range xst {
+			xst[i] = x[i] + delta
 		}
 
-		xst, yst := xt, yt
-		for range p {
-			gxst, gyst := grad(xst, yst)
-			phi := func(alpha float64) float64 {
-				return phiF(xst-alpha*gxst, yst-alpha*gyst)
-			}
-			a, b := pkg.BracketMinimum(phi)
-			alpha, _, _ := zeroordered.GoldenSectionSearch(phi, a, b, gradEps)
-			xst -= alpha * gxst
-			yst -= alpha * gyst
+		copy(xs, x)
+		err := descend(xs)
+		// спуск из x остановился в стационарной точке xs: овражный шаг её не улучшит
+		if errors.Is(err, pkg.ErrFlat) {
+			copy(x, xs)
+			break
 		}
+		if err != nil {
+			return x, phiF(x), iters, fmt.Errorf("line search: %w", err)
+		}
+		// неудачный спуск из x̃ не критичен: овражный шаг лишь уточняет xs
+		_ = descend(xst)
 
-		dx, dy := xst-xs, yst-ys
+		for i := range d {
+			d[i] = xst[i] - xs[i]
+		}
 
 		phi := func(alpha float64) float64 {
-			return phiF(xs+alpha*dx, ys+alpha*dy)
+			for i := range xs {
+				t[i] = xs[i] + alpha*d[i]
+			}
+			return phiF(t)
+		}
+		// если вдоль d минимум не локализуется, остаёмся в xs
+		var alpha float64
+		if a, _, b, err := pkg.Bracket(phi, 0, pkg.BracketStep, pkg.BracketGrow); err == nil {
+			alpha = line.Minimize(phi, a, b, gradEps).Xmin
 		}
-		a, b := pkg.BracketMinimum(phi)
-		alpha, _, _ := zeroordered.GoldenSectionSearch(phi, a, b, gradEps)
 
-		x = xs + alpha*dx
-		y = ys + alpha*dy
+		// нулевой овражный шаг: x — неподвижная точка итерации
+		moved := false
+		for i := range x {
+			xi := xs[i] + alpha*d[i]
+			moved = moved || xi != x[i]
+			x[i] = xi
+		}
+		if !moved {
+			break
+		}
 	}
 
-	return x, y, phiF(x, y), iters
+	return x, phiF(x), iters, nil
 }
 
 // NewtonModified реализует модифицированный метод Ньютона для двумерной функции f(x, y),
@@ -355,7 +572,9 @@ func RavineGradientDescent(
 // - grad: функция, возвращающая (∂f/∂x, ∂f/∂y);
 // - hess: функция, возвращающая элементы Гессиана (hxx, hxy, hyx, hyy);
 // - x0, y0: начальная точка;
-// - gradEps: порог по норме градиента для остановы.
+// - gradEps: порог по норме градиента для остановы;
+// - line: одномерный метод поиска шага α (например, zeroordered.GoldenSection);
+// - tr: трасса, в которую записывается каждое приближение (x_k, y_k), или nil.
 //
 // Особенности:
 // - Квадратичная сходимость при окрестности решения и невырожденном Гессиане.
@@ -364,46 +583,83 @@ func RavineGradientDescent(
 // - Каждая итерация делает одну двумерную обратную матрицу + одну одномерную оптимизацию.
 //
 // Возвращает координаты xmin, ymin — найденного минимума, fmin — значение f в этой точке,
-// и iters — число вызовов f (для оценки вычислительной стоимости).
+// и iters — число вызовов f (для оценки вычислительной стоимости). Если минимум вдоль p
+// не локализуется, возвращается ошибка, оборачивающая pkg.ErrNoBracket; если матрица Гессе
+// вырождена, возвращается достигнутая точка и ошибка, оборачивающая pkg.ErrSingular.
 func NewtonModified(
 	f func(x, y float64) float64,
 	grad func(x, y float64) (gx, gy float64),
 	hess func(x, y float64) (hxx, hxy, hyx, hyy float64),
 	x0, y0, gradEps float64,
-) (xmin, ymin, fmin float64, iters int) {
-	x, y := x0, y0
+	line pkg.LineMinimizer,
+	tr *pkg.Trace,
+) (xmin, ymin, fmin float64, iters int, err error) {
+	x, fmin, iters, err := NewtonModifiedN(func2(f), grad2(grad), hess2(hess), []float64{x0, y0}, gradEps, line, tr)
+	return x[0], x[1], fmin, iters, err
+}
 
-	phiF := func(x_, y_ float64) float64 {
+// NewtonModifiedN — модифицированный метод Ньютона для функции n переменных.
+// Алгоритм и параметры — как у NewtonModified; grad записывает ∇f(x) в g,
+// hess записывает матрицу Гессе n×n в h построчно (h[i*n+j] = ∂²f/∂x_i∂x_j).
+// Направление p = –H⁻¹∇f находится методом Гаусса (pkg.SolveGauss), а при n = 2 —
+// явным обращением матрицы 2×2, как в NewtonModified.
+//
+// Возвращает точку минимума xmin, значение f в ней fmin, число вызовов f (iters)
+// и ошибку — как у NewtonModified.
+func NewtonModifiedN(
+	f func(x []float64) float64,
+	grad func(x, g []float64),
+	hess func(x, h []float64),
+	x0 []float64,
+	gradEps float64,
+	line pkg.LineMinimizer,
+	tr *pkg.Trace,
+) (xmin []float64, fmin float64, iters int, err error) {
+	n := len(x0)
+	x := slices.Clone(x0)
+	g := make([]float64, n)
+	h := make([]float64, n*n)
+	t := make([]float64, n)
+
+	phiF := func(x_ []float64) float64 {
 		iters++
-		return f(x_, y_)
+		return f(x_)
 	}
 
 	for {
-		gx, gy := grad(x, y)
-		if math.Hypot(gx, gy) <= gradEps {
+		tr.Add(x...)
+		grad(x, g)
+		if norm(g) <= gradEps {
 			break
 		}
 
-		hxx, hxy, hyx, hyy := hess(x, y)
-		det := hxx*hyy - hxy*hyx
-		if math.Abs(det) < 1e-14 {
-			panic("Hessian is singular")
+		hess(x, h)
+		p, err := newtonDirection(h, g, n)
+		if err != nil {
+			return x, phiF(x), iters, fmt.Errorf("newton direction: %w", err)
 		}
 
-		px := -(hyy*gx - hxy*gy) / det
-		py := -(-hyx*gx + hxx*gy) / det
-
 		phi := func(alpha float64) float64 {
-			return phiF(x+alpha*px, y+alpha*py)
+			for i := range x {
+				t[i] = x[i] + alpha*p[i]
+			}
+			return phiF(t)
 		}
-		a, b := pkg.BracketMinimum(phi)
-		alpha, _, _ := zeroordered.GoldenSectionSearch(phi, a, b, gradEps)
+		a, _, b, err := pkg.Bracket(phi, 0, pkg.BracketStep, pkg.BracketGrow)
+		if errors.Is(err, pkg.ErrFlat) {
+			break
+		}
+		if err != nil {
+			return x, phiF(x), iters, fmt.Errorf("line search: %w", err)
+		}
+		alpha := line.Minimize(phi, a, b, gradEps).Xmin
 
-		x += alpha * px
-		y += alpha * py
+		for i := range x {
+			x[i] += alpha * p[i]
+		}
 	}
 
-	return x, y, phiF(x, y), iters
+	return x, phiF(x), iters, nil
 }
 
 // QuasiNewton реализует двумерный квази-ньютоновский метод с поправкой ранга 1 (rank-1 update)
@@ -427,70 +683,122 @@ func NewtonModified(
 // - grad: функция, возвращающая её градиент (gx, gy).
 // - x0, y0: начальное приближение.
 // - gradEps: порог по норме градиента для остановы.
+// - line: одномерный метод поиска шага (например, zeroordered.GoldenSection).
+// - tr: трасса, в которую записывается каждое приближение (x_k, y_k), или nil.
 //
 // Возвращает:
 // - xmin, ymin: найденная точка минимума,
 // - fmin: значение f в ней,
-// - iters: число вызовов f (оценка стоимости вычислений).
+// - iters: число вызовов f (оценка стоимости вычислений);
+// - err: ошибка, оборачивающая pkg.ErrNoBracket, если минимум вдоль p не локализуется.
 func QuasiNewton(
 	f func(x, y float64) float64,
 	grad func(x, y float64) (gx, gy float64),
 	x0, y0, gradEps float64,
-) (xmin, ymin, fmin float64, iters int) {
-	Hxx, Hxy := 1.0, 0.0
-	Hyx, Hyy := 0.0, 1.0
+	line pkg.LineMinimizer,
+	tr *pkg.Trace,
+) (xmin, ymin, fmin float64, iters int, err error) {
+	x, fmin, iters, err := QuasiNewtonN(func2(f), grad2(grad), []float64{x0, y0}, gradEps, line, tr)
+	return x[0], x[1], fmin, iters, err
+}
 
-	var k int
-	x, y := x0, y0
+// QuasiNewtonN — квази-ньютоновский метод с поправкой ранга 1 для функции n переменных.
+// Алгоритм и трасса tr — как у QuasiNewton; аппроксимация H_k хранится как матрица n×n (построчно)
+// и сбрасывается на единичную после каждых n итераций. grad записывает ∇f(x) в g.
+//
+// Возвращает точку минимума xmin, значение f в ней fmin, число вызовов f (iters)
+// и ошибку — как у QuasiNewton.
+func QuasiNewtonN(
+	f func(x []float64) float64,
+	grad func(x, g []float64),
+	x0 []float64,
+	gradEps float64,
+	line pkg.LineMinimizer,
+	tr *pkg.Trace,
+) (xmin []float64, fmin float64, iters int, err error) {
+	n := len(x0)
+	H := make([]float64, n*n)
+	setIdentity(H, n)
 
-	phiF := func(x_, y_ float64) float64 {
+	var k int
+	x := slices.Clone(x0)
+	g := make([]float64, n)
+	gNew := make([]float64, n)
+	xNew := make([]float64, n)
+	p := make([]float64, n)
+	v := make([]float64, n)
+	gamma := make([]float64, n)
+	t := make([]float64, n)
+
+	phiF := func(x_ []float64) float64 {
 		iters++
-		return f(x_, y_)
+		return f(x_)
 	}
 
 	for {
+		tr.Add(x...)
 		k++
-		gx, gy := grad(x, y)
-		if math.Hypot(gx, gy) <= gradEps {
+		grad(x, g)
+		if norm(g) <= gradEps {
 			break
 		}
 
-		px := -(Hxx*gx + Hxy*gy)
-		py := -(Hyx*gx + Hyy*gy)
+		for i := range n {
+			var s float64
+			for j := range n {
+				s += H[i*n+j] * g[j]
+			}
+			p[i] = -s
+		}
 
 		phi := func(alpha float64) float64 {
-			return phiF(x+alpha*px, y+alpha*py)
+			for i := range x {
+				t[i] = x[i] + alpha*p[i]
+			}
+			return phiF(t)
 		}
-		a, b := pkg.BracketMinimum(phi)
-		alpha, _, _ := zeroordered.GoldenSectionSearch(phi, a, b, gradEps)
-
-		xNew, yNew := x+alpha*px, y+alpha*py
-		dx := xNew - x
-		dy := yNew - y
-
-		gx2, gy2 := grad(xNew, yNew)
-		gammaX := gx2 - gx
-		gammaY := gy2 - gy
+		a, _, b, err := pkg.Bracket(phi, 0, pkg.BracketStep, pkg.BracketGrow)
+		if errors.Is(err, pkg.ErrFlat) {
+			break
+		}
+		if err != nil {
+			return x, phiF(x), iters, fmt.Errorf("line search: %w", err)
+		}
+		alpha := line.Minimize(phi, a, b, gradEps).Xmin
 
-		v_x := dx - (Hxx*gammaX + Hxy*gammaY)
-		v_y := dy - (Hyx*gammaX + Hyy*gammaY)
+		for i := range x {
+			xNew[i] = x[i] + alpha*p[i]
+		}
+		grad(xNew, gNew)
+		for i := range gamma {
+			gamma[i] = gNew[i] - g[i]
+		}
 
-		denom := v_x*gammaX + v_y*gammaY
+		// v = δ − H γ, где δ = xNew − x
+		var denom float64
+		for i := range n {
+			var s float64
+			for j := range n {
+				s += H[i*n+j] * gamma[j]
+			}
+			v[i] = (xNew[i] - x[i]) - s
+			denom += v[i] * gamma[i]
+		}
 		if math.Abs(denom) > 1e-14 {
-			Hxx += v_x * v_x / denom
-			Hxy += v_x * v_y / denom
-			Hyx += v_y * v_x / denom
-			Hyy += v_y * v_y / denom
+			for i := range n {
+				for j := range n {
+					H[i*n+j] += v[i] * v[j] / denom
+				}
+			}
 		}
-		if k%2 == 0 {
-			Hxx, Hxy = 1.0, 0.0
-			Hyx, Hyy = 0.0, 1.0
+		if k%n == 0 {
+			setIdentity(H, n)
 		}
 
-		x, y = xNew, yNew
+		copy(x, xNew)
 	}
 
-	return x, y, phiF(x, y), iters
+	return x, phiF(x), iters, nil
 }
 
 // ConjGradFR реализует метод сопряжённых направлений Флетчера–Ривза
@@ -515,67 +823,561 @@ func QuasiNewton(
 // - grad: возвращает её градиент (gx, gy).
 // - x0, y0: начальное приближение.
 // - gradEps: порог по норме градиента.
+// - line: одномерный метод поиска шага (например, zeroordered.GoldenSection).
+// - tr: трасса, в которую записывается каждое приближение (x_k, y_k), или nil.
 //
 // Возвращает:
 // - xmin, ymin: найденную точку минимума.
 // - fmin: значение f в xmin,ymin.
 // - iters: число вызовов f.
+// - err: ошибка, оборачивающая pkg.ErrNoBracket, если минимум вдоль dₖ не локализуется.
 func ConjGradFR(
 	f func(x, y float64) float64,
 	grad func(x, y float64) (gx, gy float64),
 	x0, y0, gradEps float64,
-) (xmin, ymin, fmin float64, iters int) {
+	line pkg.LineMinimizer,
+	tr *pkg.Trace,
+) (xmin, ymin, fmin float64, iters int, err error) {
+	x, fmin, iters, err := ConjGradFRN(func2(f), grad2(grad), []float64{x0, y0}, gradEps, line, tr)
+	return x[0], x[1], fmin, iters, err
+}
+
+// ConjGradFRN — метод сопряжённых направлений Флетчера–Ривза для функции n переменных.
+// Алгоритм и трасса tr — как у ConjGradFR; направление сбрасывается на антиградиент каждые n шагов.
+// grad записывает ∇f(x) в g.
+//
+// Возвращает точку минимума xmin, значение f в ней fmin, число вызовов f (iters)
+// и ошибку — как у ConjGradFR.
+func ConjGradFRN(
+	f func(x []float64) float64,
+	grad func(x, g []float64),
+	x0 []float64,
+	gradEps float64,
+	line pkg.LineMinimizer,
+	tr *pkg.Trace,
+) (xmin []float64, fmin float64, iters int, err error) {
+	n := len(x0)
 	var k int
 	// текущее приближение
-	x, y := x0, y0
+	x := slices.Clone(x0)
+	g := make([]float64, n)
+	gNew := make([]float64, n)
+	d := make([]float64, n)
+	t := make([]float64, n)
 
-	phiF := func(x_, y_ float64) float64 {
+	phiF := func(x_ []float64) float64 {
 		iters++
-		return f(x_, y_)
+		return f(x_)
 	}
 
-	gx, gy := grad(x, y)
-	dx, dy := -gx, -gy
+	grad(x, g)
+	for i := range d {
+		d[i] = -g[i]
+	}
 
 	for {
+		tr.Add(x...)
 		k++
-		if math.Hypot(gx, gy) <= gradEps {
+		if norm(g) <= gradEps {
 			break
 		}
 
 		phi := func(alpha float64) float64 {
-			return phiF(x+alpha*dx, y+alpha*dy)
+			for i := range x {
+				t[i] = x[i] + alpha*d[i]
+			}
+			return phiF(t)
 		}
-		a, b := pkg.BracketMinimum(phi)
-		alpha, _, _ := zeroordered.GoldenSectionSearch(phi, a, b, gradEps)
+		a, _, b, err := pkg.Bracket(phi, 0, pkg.BracketStep, pkg.BracketGrow)
+		if errors.Is(err, pkg.ErrFlat) {
+			break
+		}
+		if err != nil {
+			return x, phiF(x), iters, fmt.Errorf("line search: %w", err)
+		}
+		alpha := line.Minimize(phi, a, b, gradEps).Xmin
 
-		x += alpha * dx
-		y += alpha * dy
+		for i := range x {
+			x[i] += alpha * d[i]
+		}
 
-		gxNew, gyNew := grad(x, y)
+		grad(x, gNew)
 
 		// Метод Флетчера-Ривза: β_k = (‖g_{k+1}‖²)/(‖g_k‖²)
-		num := gxNew*gxNew + gyNew*gyNew
-		den := gx*gx + gy*gy
+		var num, den float64
+		for i := range g {
+			num += gNew[i] * gNew[i]
+			den += g[i] * g[i]
+		}
 
 		var beta float64
 		if den > 0 {
 			beta = num / den
 		}
 
-		// сброс направлений каждые n=2 шага
-		if k%2 == 0 {
-			dx, dy = -gxNew, -gyNew
-		} else {
-			dx = -gxNew + beta*dx
-			dy = -gyNew + beta*dy
+		// сброс направлений каждые n шагов
+		for i := range d {
+			if k%n == 0 {
+				d[i] = -gNew[i]
+			} else {
+				d[i] = -gNew[i] + beta*d[i]
+			}
 		}
 
-		gx, gy = gxNew, gyNew
+		copy(g, gNew)
 	}
 
-	xmin, ymin = x, y
-	fmin = phiF(x, y)
+	return x, phiF(x), iters, nil
+}
+
+// NelderMead реализует метод Нелдера–Мида (метод деформируемого симплекса)
+// для минимизации функции двух переменных f(x, y) без использования производных.
+//
+// Симплекс — n + 1 точка в n-мерном пространстве (для f(x, y) — треугольник).
+// На каждой итерации худшая вершина заменяется лучшей точкой на прямой,
+// проходящей через неё и центр тяжести xo остальных вершин.
+//
+// Алгоритм (вершины упорядочены: f(x_1) ≤ ... ≤ f(x_{n+1})):
+//   - Отражение: x_r = xo + α(xo - x_{n+1}).
+//   - Если f(x_r) < f(x_1) — растяжение: x_e = xo + γ(x_r - xo); принимается лучшая из x_e и x_r.
+//   - Если f(x_1) ≤ f(x_r) < f(x_n) — принимается x_r.
+//   - Иначе сжатие: внешнее x_c = xo + ρ(x_r - xo), если f(x_r) < f(x_{n+1}),
+//     или внутреннее x_c = xo + ρ(x_{n+1} - xo); x_c принимается, если она лучше x_r (x_{n+1}).
+//   - Если сжатие не удалось — редукция: x_i = x_1 + σ(x_i - x_1) для всех i > 1.
+//   - Процесс останавливается, когда разброс значений f в вершинах и расстояние
+//     от лучшей вершины до остальных не больше eps (или после opts.MaxIter итераций).
+//
+// Параметры:
+// - f: функция двух переменных;
+// - x0, y0: начальная точка (вершина начального симплекса);
+// - eps: точность по значению функции и по размеру симплекса;
+// - opts: коэффициенты α, γ, ρ, σ, размер начального симплекса и лимиты (NelderMeadOptions).
+//
+// Особенности:
+// - Не требует ни производных, ни границ области, в отличие от CoordinateDescent.
+// - Деформируясь, симплекс вытягивается вдоль оврагов, в том числе повёрнутых относительно осей.
+// - Вырожденный симплекс (вершины почти на одной прямой) перестраивается вокруг лучшей вершины.
+// - В размерностях n > 2 стандартные коэффициенты работают плохо: opts.Adaptive подбирает их по n.
+//
+// Возвращает координаты точки минимума (xmin, ymin), значение функции в ней (fmin)
+// и общее число вызовов f (iters). Если за opts.MaxIter итераций условие остановы не выполнено,
+// возвращается лучшая вершина симплекса и ошибка, оборачивающая ErrMaxIter.
+func NelderMead(
+	f func(x, y float64) float64,
+	x0, y0, eps float64,
+	opts NelderMeadOptions,
+) (xmin, ymin, fmin float64, iters int, err error) {
+	x, fmin, iters, err := NelderMeadN(func2(f), []float64{x0, y0}, eps, opts)
+	return x[0], x[1], fmin, iters, err
+}
+
+// NelderMeadN — метод Нелдера–Мида для функции n переменных.
+// Алгоритм и параметры — как у NelderMead; начальный симплекс — x0 и x0 + h_i·e_i,
+// h_i = opts.Step·max(|x0_i|, 1).
+//
+// Возвращает точку минимума xmin, значение функции в ней fmin, общее число вызовов f (iters)
+// и ошибку — как у NelderMead.
+func NelderMeadN(
+	f func(x []float64) float64,
+	x0 []float64,
+	eps float64,
+	opts NelderMeadOptions,
+) (xmin []float64, fmin float64, iters int, err error) {
+	n := len(x0)
+	opts = opts.withDefaults(n)
+
+	phiF := func(x_ []float64) float64 {
+		iters++
+		return f(x_)
+	}
+
+	type vertex struct {
+		x []float64
+		f float64
+	}
+	simplex := make([]vertex, n+1)
+	for i := range simplex {
+		simplex[i].x = slices.Clone(x0)
+	}
+	// build строит симплекс из вершины simplex[0] и рёбер длины h[i] вдоль осей
+	build := func(h func(i int) float64) {
+		for i := 1; i <= n; i++ {
+			copy(simplex[i].x, simplex[0].x)
+			simplex[i].x[i-1] += h(i - 1)
+			simplex[i].f = phiF(simplex[i].x)
+		}
+	}
+	simplex[0].f = phiF(simplex[0].x)
+	build(func(i int) float64 { return opts.Step * max(math.Abs(x0[i]), 1) })
+
+	xo := make([]float64, n)
+	xr := make([]float64, n)
+	xe := make([]float64, n)
+	xc := make([]float64, n)
+	points := make([][]float64, n+1)
+	// along записывает в dst точку xo + t(x - xo)
+	along := func(dst, x []float64, t float64) {
+		for i := range dst {
+			dst[i] = xo[i] + t*(x[i]-xo[i])
+		}
+	}
+	// replaceWorst заменяет худшую вершину точкой x
+	replaceWorst := func(x []float64, fx float64) {
+		copy(simplex[n].x, x)
+		simplex[n].f = fx
+	}
+
+	restarts := 0
+	err = fmt.Errorf("%w: %d", ErrMaxIter, opts.MaxIter)
+	for range opts.MaxIter {
+		slices.SortStableFunc(simplex, func(a, b vertex) int { return cmp.Compare(a.f, b.f) })
+		best, worst := simplex[0], simplex[n]
+
+		var diam float64
+		for _, v := range simplex[1:] {
+			diam = max(diam, dist(v.x, best.x))
+		}
+		if worst.f-best.f <= eps && diam <= eps {
+			err = nil
+			break
+		}
+
+		for i, v := range simplex {
+			points[i] = v.x
+		}
+		if restarts < opts.MaxRestarts && simplexVolume(points) < degenerateTol {
+			restarts++
+			build(func(int) float64 { return diam })
+			continue
+		}
+
+		// центр тяжести всех вершин, кроме худшей
+		clear(xo)
+		for _, v := range simplex[:n] {
+			for i := range xo {
+				xo[i] += v.x[i] / float64(n)
+			}
+		}
+
+		along(xr, worst.x, -opts.Alpha)
+		fr := phiF(xr)
+		switch {
+		case fr < best.f:
+			along(xe, xr, opts.Gamma)
+			if fe := phiF(xe); fe < fr {
+				replaceWorst(xe, fe)
+			} else {
+				replaceWorst(xr, fr)
+			}
+		case fr < simplex[n-1].f:
+			replaceWorst(xr, fr)
+		default:
+			var accepted bool
+			if fr < worst.f {
+				along(xc, xr, opts.Rho)
+				fc := phiF(xc)
+				if accepted = fc <= fr; accepted {
+					replaceWorst(xc, fc)
+				}
+			} else {
+				along(xc, worst.x, opts.Rho)
+				fc := phiF(xc)
+				if accepted = fc < worst.f; accepted {
+					replaceWorst(xc, fc)
+				}
+			}
+			if !accepted {
+				for _, v := range simplex[1:] {
+					for i := range v.x {
+						v.x[i] = best.x[i] + opts.Sigma*(v.x[i]-best.x[i])
+					}
+				}
+				for i := 1; i <= n; i++ {
+					simplex[i].f = phiF(simplex[i].x)
+				}
+			}
+		}
+	}
+
+	best := simplex[0]
+	for _, v := range simplex[1:] {
+		if v.f < best.f {
+			best = v
+		}
+	}
+	return best.x, phiF(best.x), iters, err
+}
+
+// HookeJeeves реализует метод Хука–Дживса (метод конфигураций) для минимизации функции
+// двух переменных f(x, y) без использования производных.
+//
+// Алгоритм:
+//   - Исследующий поиск: из базисной точки по очереди делаются пробные шаги ±delta вдоль
+//     каждой координатной оси; шаг принимается, если значение f уменьшилось.
+//   - Если исследующий поиск улучшил базисную точку x_k до x_{k+1}, выполняется поиск
+//     по образцу: из точки x_{k+1} + (x_{k+1} - x_k) снова делается исследующий поиск, и так,
+//     пока движение в найденном направлении уменьшает f.
+//   - Если исследующий поиск неудачен, шаг уменьшается: delta := shrink·delta.
+//   - Процесс останавливается, когда delta станет не больше eps
+//     или число вызовов f достигнет maxEvals.
+//
+// Параметры:
+// - f: функция двух переменных;
+// - x0, y0: начальная точка;
+// - step > 0: начальная длина пробного шага delta;
+// - shrink ∈ (0, 1): коэффициент уменьшения шага;
+// - eps: наименьшая длина шага;
+// - maxEvals: наибольшее число вызовов f (при maxEvals ≤ 0 — 1000·n, n — число переменных).
+//
+// Особенности:
+//   - Как и CoordinateDescent, двигается вдоль осей, но не требует ни границ области,
+//     ни одномерной минимизации.
+//   - Поиск по образцу ускоряет движение вдоль оврагов, не параллельных осям.
+//   - Все пробные точки лежат на сетке с шагом delta, а шаг уменьшается только после
+//     неудачного поиска, поэтому для гладкой f с ограниченными множествами уровня
+//     базисные точки имеют предельную точку, в которой ∇f = 0.
+//   - Если f не ограничена снизу, поиск по образцу уходит на бесконечность;
+//     его останавливает только предел maxEvals.
+//
+// Возвращает координаты точки минимума (xmin, ymin), значение функции в ней (fmin)
+// и общее число вызовов f (iters). Ошибка err оборачивает ErrBadParams, если step ≤ 0
+// или shrink ∉ (0, 1) (тогда возвращается начальная точка), и равна ErrMaxEvals,
+// если за maxEvals вызовов f шаг не уменьшился до eps (возвращается лучшая найденная точка).
+func HookeJeeves(
+	f func(x, y float64) float64,
+	x0, y0, step, shrink, eps float64,
+	maxEvals int,
+) (xmin, ymin, fmin float64, iters int, err error) {
+	x, fmin, iters, err := HookeJeevesN(func2(f), []float64{x0, y0}, step, shrink, eps, maxEvals)
+	return x[0], x[1], fmin, iters, err
+}
+
+// HookeJeevesN — метод Хука–Дживса для функции n переменных.
+// Алгоритм, параметры и ошибки — как у HookeJeeves.
+//
+// Возвращает точку минимума xmin, значение функции в ней fmin, общее число вызовов f (iters)
+// и ошибку — как у HookeJeeves.
+func HookeJeevesN(
+	f func(x []float64) float64,
+	x0 []float64,
+	step, shrink, eps float64,
+	maxEvals int,
+) (xmin []float64, fmin float64, iters int, err error) {
+	n := len(x0)
+	if maxEvals <= 0 {
+		maxEvals = 1000 * n
+	}
+
+	phiF := func(x_ []float64) float64 {
+		iters++
+		return f(x_)
+	}
+
+	x := slices.Clone(x0)
+	if !(step > 0) {
+		return x, phiF(x), iters, fmt.Errorf("%w: step = %g, want step > 0", ErrBadParams, step)
+	}
+	if !(shrink > 0 && shrink < 1) {
+		return x, phiF(x), iters, fmt.Errorf("%w: shrink = %g, want 0 < shrink < 1", ErrBadParams, shrink)
+	}
+
+	delta := step
+	// explore выполняет исследующий поиск из точки y (fy = f(y)), изменяя y на месте
+	explore := func(y []float64, fy float64) float64 {
+		for i := range n {
+			yi := y[i]
+			y[i] = yi + delta
+			if ft := phiF(y); ft < fy {
+				fy = ft
+				continue
+			}
+			y[i] = yi - delta
+			if ft := phiF(y); ft < fy {
+				fy = ft
+				continue
+			}
+			y[i] = yi
+		}
+		return fy
+	}
+
+	fx := phiF(x)
+	prev := make([]float64, n)
+	y := make([]float64, n)
+
+	for delta > eps {
+		if iters >= maxEvals {
+			return x, fx, iters, ErrMaxEvals
+		}
+		copy(y, x)
+		fy := explore(y, fx)
+		if fy >= fx {
+			delta *= shrink
+			continue
+		}
+
+		// поиск по образцу, пока он уменьшает f
+		for fy < fx {
+			copy(prev, x)
+			copy(x, y)
+			fx = fy
+			if iters >= maxEvals {
+				break
+			}
+			for i := range y {
+				y[i] = 2*x[i] - prev[i]
+			}
+			fy = explore(y, phiF(y))
+		}
+	}
+
+	return x, phiF(x), iters, nil
+}
+
+// Powell реализует метод сопряжённых направлений Пауэлла для минимизации функции
+// двух переменных f(x, y) без использования производных.
+//
+// Алгоритм:
+//   - Начальный набор направлений u_1, ..., u_n — оси координат.
+//   - Цикл: из точки x_0 последовательно минимизируется f вдоль каждого u_i методом line
+//     (отрезок для line локализуется pkg.Bracket), получается точка x_n.
+//   - Смещение d = x_n - x_0 за цикл — новое направление. Если экстраполированная точка 2x_n - x_0
+//     лучше x_0 и выполняется условие Пауэлла, f минимизируется вдоль d, а d заменяет направление
+//     u_m, вдоль которого f убыла сильнее всего (это убывание лучше всего восполняется направлением d).
+//   - Если направления стали почти линейно зависимы, набор сбрасывается на оси координат.
+//   - Цикл повторяется, пока смещение точки или изменение f за цикл не станет не больше eps.
+//
+// Параметры:
+// - f: функция двух переменных;
+// - x0, y0: начальная точка;
+// - eps: точность по смещению и по значению функции;
+// - line: одномерный метод поиска (например, zeroordered.GoldenSection).
+//
+// Особенности:
+// - Для квадратичной функции новые направления попарно сопряжены, и минимум находится за n циклов.
+// - В отличие от CoordinateDescent, не требует границ области и не застревает в оврагах, не параллельных осям.
+// - Условие Пауэлла отказывается от замены, если она сделала бы направления линейно зависимыми.
+//
+// Возвращает координаты точки минимума (xmin, ymin), значение функции в ней (fmin)
+// и общее число вызовов f (iters). Если f постоянна вдоль направления (pkg.ErrFlat), оно пропускается;
+// если минимум вдоль направления не локализуется иначе (функция не ограничена снизу), возвращается
+// достигнутая точка и ошибка, оборачивающая pkg.ErrNoBracket: такая точка не является решением.
+func Powell(
+	f func(x, y float64) float64,
+	x0, y0, eps float64,
+	line pkg.LineMinimizer,
+) (xmin, ymin, fmin float64, iters int, err error) {
+	x, fmin, iters, err := PowellN(func2(f), []float64{x0, y0}, eps, line)
+	return x[0], x[1], fmin, iters, err
+}
+
+// PowellN — метод Пауэлла для функции n переменных.
+// Алгоритм и параметры — как у Powell.
+//
+// Возвращает точку минимума xmin, значение функции в ней fmin, общее число вызовов f (iters)
+// и ошибку — как у Powell.
+func PowellN(
+	f func(x []float64) float64,
+	x0 []float64,
+	eps float64,
+	line pkg.LineMinimizer,
+) (xmin []float64, fmin float64, iters int, err error) {
+	n := len(x0)
+	x := slices.Clone(x0)
+	t := make([]float64, n)
+
+	phiF := func(x_ []float64) float64 {
+		iters++
+		return f(x_)
+	}
+
+	// dirs — набор направлений поиска, вначале оси координат
+	dirs := make([][]float64, n)
+	resetDirs := func() {
+		for i := range dirs {
+			dirs[i] = make([]float64, n)
+			dirs[i][i] = 1
+		}
+	}
+	resetDirs()
+
+	// minimize переносит x в точку минимума f на прямой x + alpha·d и возвращает новое значение f;
+	// вдоль направления, на котором f постоянна, x не меняется
+	minimize := func(d []float64, fx float64) (float64, error) {
+		phi := func(alpha float64) float64 {
+			for i := range x {
+				t[i] = x[i] + alpha*d[i]
+			}
+			return phiF(t)
+		}
+		a, _, b, err := pkg.Bracket(phi, 0, pkg.BracketStep, pkg.BracketGrow)
+		if errors.Is(err, pkg.ErrFlat) {
+			return fx, nil
+		}
+		if err != nil {
+			return fx, fmt.Errorf("line search: %w", err)
+		}
+		res := line.Minimize(phi, a, b, eps)
+		if res.Fmin >= fx {
+			return fx, nil
+		}
+		for i := range x {
+			x[i] += res.Xmin * d[i]
+		}
+		return res.Fmin, nil
+	}
+
+	start := make([]float64, n)
+	xe := make([]float64, n)
+	// points — вершины 0, u_1, ..., u_n для проверки линейной независимости направлений
+	points := make([][]float64, n+1)
+	points[0] = make([]float64, n)
+
+	fx := phiF(x)
+	for {
+		copy(start, x)
+		fStart := fx
+
+		// m — направление, вдоль которого f убыла сильнее всего (на biggest)
+		var biggest float64
+		m := 0
+		for i, d := range dirs {
+			fPrev := fx
+			if fx, err = minimize(d, fx); err != nil {
+				return x, phiF(x), iters, err
+			}
+			if fPrev-fx > biggest {
+				biggest, m = fPrev-fx, i
+			}
+		}
+
+		if dist(x, start) <= eps || fStart-fx <= eps {
+			break
+		}
+
+		d := make([]float64, n)
+		for i := range x {
+			d[i] = x[i] - start[i]
+			xe[i] = x[i] + d[i]
+		}
+		fe := phiF(xe)
+
+		// условие Пауэлла: 2(f_0 - 2f_n + f_e)(f_0 - f_n - Δ)² < Δ(f_0 - f_e)²
+		if fe < fStart {
+			a, b := fStart-fx-biggest, fStart-fe
+			if 2*(fStart-2*fx+fe)*a*a < biggest*b*b {
+				if fx, err = minimize(d, fx); err != nil {
+					return x, phiF(x), iters, err
+				}
+				dirs = append(slices.Delete(dirs, m, m+1), d)
+			}
+		}
+
+		copy(points[1:], dirs)
+		if simplexVolume(points) < degenerateTol {
+			resetDirs()
+		}
+	}
 
-	return xmin, ymin, fmin, iters
+	return x, phiF(x), iters, nil
 }
@@ -1,8 +1,16 @@
 package multidimensional
 
 import (
+	"errors"
 	"math"
 	"testing"
+	"time"
+
+	zeroordered "github.com/vshulcz/edu_optimization_methods/internal/1_zero_ordered"
+	"github.com/vshulcz/edu_optimization_methods/pkg"
+	"github.com/vshulcz/edu_optimization_methods/pkg/autodiff"
+	"github.com/vshulcz/edu_optimization_methods/pkg/autodiff/reverse"
+	"github.com/vshulcz/edu_optimization_methods/pkg/numdiff"
 )
 
 func TestCoordinateDescent(t *testing.T) {
@@ -46,7 +54,7 @@ func TestCoordinateDescent(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotXmin, gotYmin, gotFmin, gotIters := CoordinateDescent(tt.args.f, tt.args.x0, tt.args.y0, tt.args.ax, tt.args.bx, tt.args.ay, tt.args.by, tt.args.eps)
+			gotXmin, gotYmin, gotFmin, gotIters := CoordinateDescent(tt.args.f, tt.args.x0, tt.args.y0, tt.args.ax, tt.args.bx, tt.args.ay, tt.args.by, tt.args.eps, zeroordered.GoldenSection)
 			if math.Abs(gotXmin-tt.wantXmin) > 1e-4 {
 				t.Errorf("CoordinateDescent() gotXmin = %v, want %v", gotXmin, tt.wantXmin)
 			}
@@ -155,7 +163,7 @@ func TestSteepestGradientDescent(t *testing.T) {
 			wantXmin:  -0.613225,
 			wantYmin:  -0.663293,
 			wantFmin:  -1.805292,
-			wantIters: 166,
+			wantIters: 163,
 		},
 		{
 			name: "Case 2: f(x,y) = 9*x*x + y*y",
@@ -170,15 +178,18 @@ func TestSteepestGradientDescent(t *testing.T) {
 				y0:      1.0,
 				gradEps: 0.05,
 			},
-			wantXmin:  0.0009394268730375316,
-			wantYmin:  0.01440979491160928,
-			wantFmin:  0.00021558489504270635,
+			wantXmin:  -0.0008333821059019354,
+			wantYmin:  0.014611905148169473,
+			wantFmin:  0.00021975850366903944,
 			wantIters: 141,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotXmin, gotYmin, gotFmin, gotIters := SteepestGradientDescent(tt.args.f, tt.args.grad, tt.args.x0, tt.args.y0, tt.args.gradEps)
+			gotXmin, gotYmin, gotFmin, gotIters, err := SteepestGradientDescent(tt.args.f, tt.args.grad, tt.args.x0, tt.args.y0, tt.args.gradEps, zeroordered.GoldenSection, nil)
+			if err != nil {
+				t.Fatalf("SteepestGradientDescent() error = %v", err)
+			}
 			if math.Abs(gotXmin-tt.wantXmin) > 1e-6 {
 				t.Errorf("SteepestGradientDescent() gotXmin = %v, want %v", gotXmin, tt.wantXmin)
 			}
@@ -229,7 +240,7 @@ func TestAcceleratedGradientDescent(t *testing.T) {
 			wantXmin:  -0.613225,
 			wantYmin:  -0.663293,
 			wantFmin:  -1.805292,
-			wantIters: 205,
+			wantIters: 203,
 		},
 		{
 			name: "Case 2: f(x,y) = 9*x*x + y*y",
@@ -245,15 +256,18 @@ func TestAcceleratedGradientDescent(t *testing.T) {
 				p:       2,
 				gradEps: 0.05,
 			},
-			wantXmin:  -0.0001747128435803141,
-			wantYmin:  -0.0034917214256659033,
-			wantFmin:  1.2466839713861601e-05,
-			wantIters: 103,
+			wantXmin:  -5.778524475346346e-05,
+			wantYmin:  0.018799924146169062,
+			wantFmin:  0.0003534672001123115,
+			wantIters: 91,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotXmin, gotYmin, gotFmin, gotIters := AcceleratedGradientDescent(tt.args.f, tt.args.grad, tt.args.x0, tt.args.y0, tt.args.p, tt.args.gradEps)
+			gotXmin, gotYmin, gotFmin, gotIters, err := AcceleratedGradientDescent(tt.args.f, tt.args.grad, tt.args.x0, tt.args.y0, tt.args.p, tt.args.gradEps, zeroordered.GoldenSection)
+			if err != nil {
+				t.Fatalf("AcceleratedGradientDescent() error = %v", err)
+			}
 			if math.Abs(gotXmin-tt.wantXmin) > 1e-6 {
 				t.Errorf("AcceleratedGradientDescent() gotXmin = %v, want %v", gotXmin, tt.wantXmin)
 			}
@@ -305,7 +319,7 @@ func TestRavineGradientDescent(t *testing.T) {
 			wantXmin:  -0.613225,
 			wantYmin:  -0.663293,
 			wantFmin:  -1.805292,
-			wantIters: 496,
+			wantIters: 334,
 		},
 		{
 			name: "Case 2: f(x,y) = 9*x*x + y*y",
@@ -321,15 +335,18 @@ func TestRavineGradientDescent(t *testing.T) {
 				p:       2,
 				gradEps: 0.05,
 			},
-			wantXmin:  0.0009394268730375316,
-			wantYmin:  0.0144097949116092,
-			wantFmin:  0.00021558489504270635,
-			wantIters: 351,
+			wantXmin:  -0.0008333821059019354,
+			wantYmin:  0.014611905148169473,
+			wantFmin:  0.00021975850366903944,
+			wantIters: 302,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotXmin, gotYmin, gotFmin, gotIters := RavineGradientDescent(tt.args.f, tt.args.grad, tt.args.x0, tt.args.y0, tt.args.delta, tt.args.p, tt.args.gradEps)
+			gotXmin, gotYmin, gotFmin, gotIters, err := RavineGradientDescent(tt.args.f, tt.args.grad, tt.args.x0, tt.args.y0, tt.args.delta, tt.args.p, tt.args.gradEps, zeroordered.GoldenSection)
+			if err != nil {
+				t.Fatalf("RavineGradientDescent() error = %v", err)
+			}
 			if math.Abs(gotXmin-tt.wantXmin) > 1e-6 {
 				t.Errorf("RavineGradientDescent() gotXmin = %v, want %v", gotXmin, tt.wantXmin)
 			}
@@ -409,15 +426,18 @@ func TestNewtonModified(t *testing.T) {
 				y0:      1.0,
 				gradEps: 0.05,
 			},
-			wantXmin:  0.0004531268730375316,
-			wantYmin:  0.000453197949116092,
-			wantFmin:  0.0000021558489504270,
+			wantXmin:  0.00012704091805911498,
+			wantYmin:  0.00012704091805911498,
+			wantFmin:  1.6139394861302767e-07,
 			wantIters: 29,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotXmin, gotYmin, gotFmin, gotIters := NewtonModified(tt.args.f, tt.args.grad, tt.args.hess, tt.args.x0, tt.args.y0, tt.args.gradEps)
+			gotXmin, gotYmin, gotFmin, gotIters, err := NewtonModified(tt.args.f, tt.args.grad, tt.args.hess, tt.args.x0, tt.args.y0, tt.args.gradEps, zeroordered.GoldenSection, nil)
+			if err != nil {
+				t.Fatalf("NewtonModified() error = %v", err)
+			}
 			if math.Abs(gotXmin-tt.wantXmin) > 1e-6 {
 				t.Errorf("NewtonModified() gotXmin = %v, want %v", gotXmin, tt.wantXmin)
 			}
@@ -466,7 +486,7 @@ func TestQuasiNewton(t *testing.T) {
 			wantXmin:  -0.613225,
 			wantYmin:  -0.663293,
 			wantFmin:  -1.805292,
-			wantIters: 166,
+			wantIters: 163,
 		},
 		{
 			name: "Case 2: f(x,y) = 9*x*x + y*y",
@@ -481,15 +501,18 @@ func TestQuasiNewton(t *testing.T) {
 				y0:      1.0,
 				gradEps: 0.05,
 			},
-			wantXmin:  0.0016676491865052516,
-			wantYmin:  0.0022439658770094474,
-			wantFmin:  3.0064867140447425e-05,
-			wantIters: 51,
+			wantXmin:  0.0018846629718983119,
+			wantYmin:  0.0027802893948723657,
+			wantFmin:  3.9697599778040936e-05,
+			wantIters: 45,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotXmin, gotYmin, gotFmin, gotIters := QuasiNewton(tt.args.f, tt.args.grad, tt.args.x0, tt.args.y0, tt.args.gradEps)
+			gotXmin, gotYmin, gotFmin, gotIters, err := QuasiNewton(tt.args.f, tt.args.grad, tt.args.x0, tt.args.y0, tt.args.gradEps, zeroordered.GoldenSection, nil)
+			if err != nil {
+				t.Fatalf("QuasiNewton() error = %v", err)
+			}
 			if math.Abs(gotXmin-tt.wantXmin) > 1e-6 {
 				t.Errorf("QuasiNewton() gotXmin = %v, want %v", gotXmin, tt.wantXmin)
 			}
@@ -538,7 +561,7 @@ func TestConjGradFR(t *testing.T) {
 			wantXmin:  -0.613225,
 			wantYmin:  -0.663293,
 			wantFmin:  -1.805292,
-			wantIters: 166,
+			wantIters: 163,
 		},
 		{
 			name: "Case 2: f(x,y) = 9*x*x + y*y",
@@ -553,15 +576,18 @@ func TestConjGradFR(t *testing.T) {
 				y0:      1.0,
 				gradEps: 0.05,
 			},
-			wantXmin:  0.0004625978729986543,
-			wantYmin:  0.0023294319568673144,
-			wantFmin:  7.352224370600598e-06,
-			wantIters: 61,
+			wantXmin:  -0.0006157568162331291,
+			wantYmin:  -0.008736464842913825,
+			wantFmin:  7.973822606210731e-05,
+			wantIters: 51,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotXmin, gotYmin, gotFmin, gotIters := ConjGradFR(tt.args.f, tt.args.grad, tt.args.x0, tt.args.y0, tt.args.gradEps)
+			gotXmin, gotYmin, gotFmin, gotIters, err := ConjGradFR(tt.args.f, tt.args.grad, tt.args.x0, tt.args.y0, tt.args.gradEps, zeroordered.GoldenSection, nil)
+			if err != nil {
+				t.Fatalf("ConjGradFR() error = %v", err)
+			}
 			if math.Abs(gotXmin-tt.wantXmin) > 1e-6 {
 				t.Errorf("ConjGradFR() gotXmin = %v, want %v", gotXmin, tt.wantXmin)
 			}
@@ -577,3 +603,749 @@ func TestConjGradFR(t *testing.T) {
 		})
 	}
 }
+
+func TestLineMinimizers(t *testing.T) {
+	f := func(x, y float64) float64 {
+		return x*x + math.Exp(x*x+y*y) + 4*x + 3*y
+	}
+	grad := func(x, y float64) (gx, gy float64) {
+		return 2*x + 2*x*math.Exp(x*x+y*y) + 4, 2*y*math.Exp(x*x+y*y) + 3
+	}
+	hess := func(x, y float64) (hxx, hxy, hyx, hyy float64) {
+		e := math.Exp(x*x + y*y)
+		hxy = 4 * x * y * e
+		return 2 + 2*e + 4*x*x*e, hxy, hxy, 2*e + 4*y*y*e
+	}
+	wantXmin, wantYmin := -0.613225, -0.663293
+	lines := map[string]pkg.LineMinimizer{
+		"GoldenSection": zeroordered.GoldenSection,
+		"Fibonacci":     zeroordered.Fibonacci,
+		"Dichotomy":     zeroordered.Dichotomy{},
+		"Brent":         zeroordered.Brent,
+		"Parabolic":     zeroordered.Parabolic,
+	}
+	methods := map[string]func(line pkg.LineMinimizer) (xmin, ymin float64, err error){
+		"CoordinateDescent": func(line pkg.LineMinimizer) (float64, float64, error) {
+			x, y, _, _ := CoordinateDescent(f, 1, 1, -4, 4, -4, 4, 1e-6, line)
+			return x, y, nil
+		},
+		"SteepestGradientDescent": func(line pkg.LineMinimizer) (float64, float64, error) {
+			x, y, _, _, err := SteepestGradientDescent(f, grad, 1, 1, 1e-6, line, nil)
+			return x, y, err
+		},
+		"NewtonModified": func(line pkg.LineMinimizer) (float64, float64, error) {
+			x, y, _, _, err := NewtonModified(f, grad, hess, 1, 1, 1e-6, line, nil)
+			return x, y, err
+		},
+		"QuasiNewton": func(line pkg.LineMinimizer) (float64, float64, error) {
+			x, y, _, _, err := QuasiNewton(f, grad, 1, 1, 1e-6, line, nil)
+			return x, y, err
+		},
+		"ConjGradFR": func(line pkg.LineMinimizer) (float64, float64, error) {
+			x, y, _, _, err := ConjGradFR(f, grad, 1, 1, 1e-6, line, nil)
+			return x, y, err
+		},
+	}
+	for mName, method := range methods {
+		for lName, line := range lines {
+			gotXmin, gotYmin, err := method(line)
+			if err != nil {
+				t.Errorf("%s with %s: error = %v", mName, lName, err)
+			}
+			if math.Abs(gotXmin-wantXmin) > 1e-4 || math.Abs(gotYmin-wantYmin) > 1e-4 {
+				t.Errorf("%s with %s: got (%v, %v), want (%v, %v)", mName, lName, gotXmin, gotYmin, wantXmin, wantYmin)
+			}
+		}
+	}
+}
+
+func TestSteepestConvergenceRate(t *testing.T) {
+	// f(x, y) = x² + 10y² из точки (10, 1): наихудший случай наискорейшего спуска,
+	// погрешность убывает линейно со знаменателем (κ - 1) / (κ + 1) = 9/11
+	f := func(x, y float64) float64 { return x*x + 10*y*y }
+	grad := func(x, y float64) (gx, gy float64) { return 2 * x, 20 * y }
+
+	var tr pkg.Trace
+	if _, _, _, _, err := SteepestGradientDescent(f, grad, 10, 1, 1e-6, zeroordered.GoldenSection, &tr); err != nil {
+		t.Fatalf("SteepestGradientDescent() error = %v", err)
+	}
+	order, rate, err := pkg.EstimateConvergence(tr.Errors(0, 0))
+	if err != nil {
+		t.Fatalf("EstimateConvergence() error = %v", err)
+	}
+	if math.Abs(order-1) > 0.01 {
+		t.Errorf("order = %v, want 1", order)
+	}
+	if want := 9.0 / 11; math.Abs(rate-want) > 0.005 {
+		t.Errorf("rate = %v, want %v", rate, want)
+	}
+}
+
+func TestNDimensional(t *testing.T) {
+	// f(x) = ½xᵀAx − bᵀx, A = [[4, 1, 0], [1, 3, 1], [0, 1, 2]], b = A·(1, −1, 2)
+	A := []float64{4, 1, 0, 1, 3, 1, 0, 1, 2}
+	b := []float64{3, 0, 3}
+	want := []float64{1, -1, 2}
+	f := func(x []float64) float64 {
+		var s float64
+		for i := range 3 {
+			for j := range 3 {
+				s += 0.5 * x[i] * A[i*3+j] * x[j]
+			}
+			s -= b[i] * x[i]
+		}
+		return s
+	}
+	grad := func(x, g []float64) {
+		for i := range 3 {
+			g[i] = -b[i]
+			for j := range 3 {
+				g[i] += A[i*3+j] * x[j]
+			}
+		}
+	}
+	hess := func(x, h []float64) {
+		copy(h, A)
+	}
+	x0 := []float64{0, 0, 0}
+	line := zeroordered.GoldenSection
+	methods := map[string]func() (xmin []float64, fmin float64, iters int, err error){
+		"CoordinateDescentN": func() ([]float64, float64, int, error) {
+			x, fmin, iters := CoordinateDescentN(f, x0, []float64{-5, -5, -5}, []float64{5, 5, 5}, 1e-8, line)
+			return x, fmin, iters, nil
+		},
+		"GradientDescentBacktrackingN": func() ([]float64, float64, int, error) {
+			x, fmin, iters := GradientDescentBacktrackingN(f, grad, x0, 1, 1e-4, 0.5, 1e-7)
+			return x, fmin, iters, nil
+		},
+		"SteepestGradientDescentN": func() ([]float64, float64, int, error) {
+			return SteepestGradientDescentN(f, grad, x0, 1e-7, line, nil)
+		},
+		"AcceleratedGradientDescentN": func() ([]float64, float64, int, error) {
+			return AcceleratedGradientDescentN(f, grad, x0, 3, 1e-7, line)
+		},
+		"RavineGradientDescentN": func() ([]float64, float64, int, error) {
+			return RavineGradientDescentN(f, grad, x0, 0.5, 1, 1e-7, line)
+		},
+		"NewtonModifiedN": func() ([]float64, float64, int, error) {
+			return NewtonModifiedN(f, grad, hess, x0, 1e-7, line, nil)
+		},
+		"QuasiNewtonN": func() ([]float64, float64, int, error) {
+			return QuasiNewtonN(f, grad, x0, 1e-7, line, nil)
+		},
+		"ConjGradFRN": func() ([]float64, float64, int, error) {
+			return ConjGradFRN(f, grad, x0, 1e-7, line, nil)
+		},
+		"NelderMeadN": func() ([]float64, float64, int, error) {
+			return NelderMeadN(f, x0, 1e-10, NelderMeadOptions{})
+		},
+		"HookeJeevesN": func() ([]float64, float64, int, error) {
+			return HookeJeevesN(f, x0, 0.5, 0.5, 1e-8, 0)
+		},
+		"PowellN": func() ([]float64, float64, int, error) {
+			return PowellN(f, x0, 1e-8, line)
+		},
+	}
+	for name, method := range methods {
+		t.Run(name, func(t *testing.T) {
+			xmin, fmin, iters, err := method()
+			if err != nil {
+				t.Fatalf("%s() error = %v", name, err)
+			}
+			for i := range want {
+				if math.Abs(xmin[i]-want[i]) > 1e-4 {
+					t.Errorf("%s() xmin = %v, want %v", name, xmin, want)
+					break
+				}
+			}
+			if wantF := f(want); math.Abs(fmin-wantF) > 1e-8 {
+				t.Errorf("%s() fmin = %v, want %v", name, fmin, wantF)
+			}
+			if iters == 0 {
+				t.Errorf("%s() iters = 0", name)
+			}
+			if x0[0] != 0 || x0[1] != 0 || x0[2] != 0 {
+				t.Fatalf("%s() modified x0 = %v", name, x0)
+			}
+		})
+	}
+}
+
+func TestNDimensionalAutodiff(t *testing.T) {
+	// расширенная функция Розенброка от n = 4 переменных, минимум в (1, 1, 1, 1);
+	// градиент строится обратным автоматическим дифференцированием и комплексным шагом
+	f := func(x []float64) float64 {
+		var s float64
+		for i := 0; i+1 < len(x); i++ {
+			s += 100*math.Pow(x[i+1]-x[i]*x[i], 2) + math.Pow(1-x[i], 2)
+		}
+		return s
+	}
+	tests := []struct {
+		name string
+		grad func(x, g []float64)
+	}{
+		{
+			name: "reverse.GradN",
+			grad: reverse.GradN(func(x []reverse.Value) reverse.Value {
+				s := reverse.Const(0)
+				for i := 0; i+1 < len(x); i++ {
+					a := x[i+1].Sub(x[i].Mul(x[i]))
+					b := reverse.Const(1).Sub(x[i])
+					s = s.Add(a.Mul(a).MulConst(100)).Add(b.Mul(b))
+				}
+				return s
+			}),
+		},
+		{
+			name: "numdiff.ComplexGradN",
+			grad: numdiff.ComplexGradN(func(z []complex128) complex128 {
+				var s complex128
+				for i := 0; i+1 < len(z); i++ {
+					a := z[i+1] - z[i]*z[i]
+					s += 100*a*a + (1-z[i])*(1-z[i])
+				}
+				return s
+			}),
+		},
+	}
+	want := []float64{1, 1, 1, 1}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			xmin, fmin, _, err := QuasiNewtonN(f, tt.grad, []float64{-1.2, 1, -1.2, 1}, 1e-7, zeroordered.GoldenSection, nil)
+			if err != nil {
+				t.Fatalf("QuasiNewtonN() error = %v", err)
+			}
+			for i := range want {
+				if math.Abs(xmin[i]-want[i]) > 1e-4 {
+					t.Errorf("QuasiNewtonN() xmin = %v, want %v", xmin, want)
+					break
+				}
+			}
+			if fmin > 1e-8 {
+				t.Errorf("QuasiNewtonN() fmin = %v, want 0", fmin)
+			}
+		})
+	}
+}
+
+func TestNewtonModifiedNAutodiff(t *testing.T) {
+	// расширенная функция Розенброка от n = 4 переменных: градиент и матрица Гессе
+	// строятся прямым автоматическим дифференцированием (гипердуальными числами);
+	// старт из нуля, а не из (-1.2, 1, ...), где метод Ньютона уходит к локальному минимуму f ≈ 3.70
+	rosenbrock := func(x []autodiff.Dual) autodiff.Dual {
+		s := autodiff.Const(0)
+		for i := 0; i+1 < len(x); i++ {
+			a := x[i+1].Sub(x[i].Mul(x[i]))
+			b := autodiff.Const(1).Sub(x[i])
+			s = s.Add(a.Mul(a).MulConst(100)).Add(b.Mul(b))
+		}
+		return s
+	}
+	f, grad, hess := autodiff.FuncN(rosenbrock), autodiff.GradN(rosenbrock), autodiff.HessN(rosenbrock)
+	xmin, fmin, _, err := NewtonModifiedN(f, grad, hess, []float64{0, 0, 0, 0}, 1e-7, zeroordered.GoldenSection, nil)
+	if err != nil {
+		t.Fatalf("NewtonModifiedN() error = %v", err)
+	}
+	for i := range xmin {
+		if math.Abs(xmin[i]-1) > 1e-4 {
+			t.Errorf("NewtonModifiedN() xmin = %v, want [1 1 1 1]", xmin)
+			break
+		}
+	}
+	if fmin > 1e-8 {
+		t.Errorf("NewtonModifiedN() fmin = %v, want 0", fmin)
+	}
+}
+
+func TestNelderMead(t *testing.T) {
+	type args struct {
+		f    func(x, y float64) float64
+		x0   float64
+		y0   float64
+		eps  float64
+		opts NelderMeadOptions
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantXmin  float64
+		wantYmin  float64
+		wantFmin  float64
+		wantIters int
+	}{
+		{
+			name: "Case 1: f(x,y) = x*x + math.Exp(x*x+y*y) + 4*x + 3*y",
+			args: args{
+				f:   pkg.F2,
+				x0:  1.0,
+				y0:  1.0,
+				eps: 1e-6,
+			},
+			wantXmin:  -0.613225,
+			wantYmin:  -0.663293,
+			wantFmin:  -1.805292,
+			wantIters: 113,
+		},
+		{
+			name: "Case 2: f(x,y) = 100*(y-x*x)^2 + (1-x)^2",
+			args: args{
+				f: func(x, y float64) float64 {
+					return 100*(y-x*x)*(y-x*x) + (1-x)*(1-x)
+				},
+				x0:  -1.2,
+				y0:  1.0,
+				eps: 1e-8,
+			},
+			wantXmin:  1.0,
+			wantYmin:  1.0,
+			wantFmin:  0.0,
+			wantIters: 262,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotXmin, gotYmin, gotFmin, gotIters, err := NelderMead(tt.args.f, tt.args.x0, tt.args.y0, tt.args.eps, tt.args.opts)
+			if err != nil {
+				t.Fatalf("NelderMead() error = %v", err)
+			}
+			if math.Abs(gotXmin-tt.wantXmin) > 1e-4 {
+				t.Errorf("NelderMead() gotXmin = %v, want %v", gotXmin, tt.wantXmin)
+			}
+			if math.Abs(gotYmin-tt.wantYmin) > 1e-4 {
+				t.Errorf("NelderMead() gotYmin = %v, want %v", gotYmin, tt.wantYmin)
+			}
+			if math.Abs(gotFmin-tt.wantFmin) > 1e-4 {
+				t.Errorf("NelderMead() gotFmin = %v, want %v", gotFmin, tt.wantFmin)
+			}
+			if gotIters != tt.wantIters {
+				t.Errorf("NelderMead() gotIters = %v, want %v", gotIters, tt.wantIters)
+			}
+		})
+	}
+}
+
+func TestNelderMeadRotatedValley(t *testing.T) {
+	// f(x, y) = (x + y)² + 100(x − y)²: овраг вдоль прямой y = −x, повёрнутой на 45° к осям.
+	// Покоординатный спуск движется по оврагу мелкими зигзагами, симплекс вытягивается вдоль него.
+	f := func(x, y float64) float64 {
+		u, v := x+y, x-y
+		return u*u + 100*v*v
+	}
+	x, y, _, iters, err := NelderMead(f, 3, -1, 1e-8, NelderMeadOptions{})
+	if err != nil {
+		t.Fatalf("NelderMead() error = %v", err)
+	}
+	if math.Hypot(x, y) > 1e-6 {
+		t.Errorf("NelderMead() = (%v, %v), want (0, 0)", x, y)
+	}
+	cx, cy, _, cdIters := CoordinateDescent(f, 3, -1, -5, 5, -5, 5, 1e-8, zeroordered.GoldenSection)
+	if iters*10 > cdIters {
+		t.Errorf("NelderMead() iters = %v, CoordinateDescent() iters = %v (at (%v, %v))", iters, cdIters, cx, cy)
+	}
+}
+
+func TestNelderMeadHighDimensions(t *testing.T) {
+	// f(x) = Σ(x_i − 1)²: при больших n стандартные коэффициенты вырождают симплекс,
+	// и без перезапусков метод не сходится за отведённые итерации (ErrMaxIter)
+	sphere := func(x []float64) float64 {
+		var s float64
+		for _, v := range x {
+			s += (v - 1) * (v - 1)
+		}
+		return s
+	}
+	tests := []struct {
+		name    string
+		n       int
+		opts    NelderMeadOptions
+		wantErr bool
+	}{
+		{name: "n = 30, standard", n: 30, opts: NelderMeadOptions{}},
+		{name: "n = 30, standard without restarts", n: 30, opts: NelderMeadOptions{MaxRestarts: -1}, wantErr: true},
+		{name: "n = 30, adaptive without restarts", n: 30, opts: NelderMeadOptions{Adaptive: true, MaxRestarts: -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			xmin, _, _, err := NelderMeadN(sphere, make([]float64, tt.n), 1e-8, tt.opts)
+			if gotErr := errors.Is(err, ErrMaxIter); gotErr != tt.wantErr {
+				t.Errorf("NelderMeadN() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var e float64
+			for _, v := range xmin {
+				e = max(e, math.Abs(v-1))
+			}
+			if gotErr := e > 1e-6; gotErr != tt.wantErr {
+				t.Errorf("NelderMeadN() max |x_i - 1| = %v, wantErr %v", e, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSimplexVolume(t *testing.T) {
+	tests := []struct {
+		name    string
+		simplex [][]float64
+		want    float64
+	}{
+		{name: "right triangle", simplex: [][]float64{{0, 0}, {1, 0}, {0, 2}}, want: 1},
+		{name: "equilateral triangle", simplex: [][]float64{{0, 0}, {1, 0}, {0.5, math.Sqrt(3) / 2}}, want: math.Sqrt(3) / 2},
+		{name: "collinear vertices", simplex: [][]float64{{0, 0}, {1, 1}, {3, 3}}, want: 0},
+		{name: "coincident vertices", simplex: [][]float64{{1, 2, 3}, {1, 2, 3}, {0, 1, 0}, {0, 0, 1}}, want: 0},
+		{name: "coplanar tetrahedron", simplex: [][]float64{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}, {1, 1, 0}}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := simplexVolume(tt.simplex); math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("simplexVolume() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHookeJeeves(t *testing.T) {
+	type args struct {
+		f      func(x, y float64) float64
+		x0     float64
+		y0     float64
+		step   float64
+		shrink float64
+		eps    float64
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantXmin  float64
+		wantYmin  float64
+		wantFmin  float64
+		wantIters int
+	}{
+		{
+			name: "Case 1: f(x,y) = x*x + math.Exp(x*x+y*y) + 4*x + 3*y",
+			args: args{
+				f:      pkg.F2,
+				x0:     1.0,
+				y0:     1.0,
+				step:   0.5,
+				shrink: 0.5,
+				eps:    1e-6,
+			},
+			wantXmin:  -0.613225,
+			wantYmin:  -0.663293,
+			wantFmin:  -1.805292,
+			wantIters: 216,
+		},
+		{
+			name: "Case 2: f(x,y) = 100*(y-x*x)^2 + (1-x)^2",
+			args: args{
+				f: func(x, y float64) float64 {
+					return 100*(y-x*x)*(y-x*x) + (1-x)*(1-x)
+				},
+				x0:     -1.2,
+				y0:     1.0,
+				step:   0.5,
+				shrink: 0.5,
+				eps:    1e-8,
+			},
+			wantXmin:  1.0,
+			wantYmin:  1.0,
+			wantFmin:  0.0,
+			wantIters: 462,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotXmin, gotYmin, gotFmin, gotIters, err := HookeJeeves(tt.args.f, tt.args.x0, tt.args.y0, tt.args.step, tt.args.shrink, tt.args.eps, 0)
+			if err != nil {
+				t.Fatalf("HookeJeeves() error = %v", err)
+			}
+			if math.Abs(gotXmin-tt.wantXmin) > 1e-4 {
+				t.Errorf("HookeJeeves() gotXmin = %v, want %v", gotXmin, tt.wantXmin)
+			}
+			if math.Abs(gotYmin-tt.wantYmin) > 1e-4 {
+				t.Errorf("HookeJeeves() gotYmin = %v, want %v", gotYmin, tt.wantYmin)
+			}
+			if math.Abs(gotFmin-tt.wantFmin) > 1e-4 {
+				t.Errorf("HookeJeeves() gotFmin = %v, want %v", gotFmin, tt.wantFmin)
+			}
+			if gotIters != tt.wantIters {
+				t.Errorf("HookeJeeves() gotIters = %v, want %v", gotIters, tt.wantIters)
+			}
+		})
+	}
+}
+
+func TestHookeJeevesErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		f        func(x, y float64) float64
+		step     float64
+		shrink   float64
+		maxEvals int
+		wantErr  error
+	}{
+		{name: "shrink = 1", f: pkg.F2, step: 0.5, shrink: 1, wantErr: ErrBadParams},
+		{name: "shrink = 0", f: pkg.F2, step: 0.5, shrink: 0, wantErr: ErrBadParams},
+		{name: "shrink is NaN", f: pkg.F2, step: 0.5, shrink: math.NaN(), wantErr: ErrBadParams},
+		{name: "step = 0", f: pkg.F2, step: 0, shrink: 0.5, wantErr: ErrBadParams},
+		{name: "step < 0", f: pkg.F2, step: -1, shrink: 0.5, wantErr: ErrBadParams},
+		// поиск по образцу уходит на бесконечность
+		{
+			name:    "unbounded f(x,y) = x + y",
+			f:       func(x, y float64) float64 { return x + y },
+			step:    0.5,
+			shrink:  0.5,
+			wantErr: ErrMaxEvals,
+		},
+		{name: "maxEvals", f: pkg.F2, step: 0.5, shrink: 0.5, maxEvals: 50, wantErr: ErrMaxEvals},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y, fmin, iters, err := HookeJeeves(tt.f, 1, 1, tt.step, tt.shrink, 1e-6, tt.maxEvals)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("HookeJeeves() error = %v, want %v", err, tt.wantErr)
+			}
+			// предел maxEvals проверяется перед каждым исследующим поиском (не больше 2n + 1 вызовов f)
+			limit := tt.maxEvals
+			if limit <= 0 {
+				limit = 1000 * 2
+			}
+			if iters > limit+2*2+1 {
+				t.Errorf("HookeJeeves() iters = %v, want at most %v", iters, limit+2*2+1)
+			}
+			if errors.Is(err, ErrBadParams) && (x != 1 || y != 1) {
+				t.Errorf("HookeJeeves() = (%v, %v), want the initial point (1, 1)", x, y)
+			}
+			if wantF := tt.f(x, y); fmin != wantF {
+				t.Errorf("HookeJeeves() fmin = %v, want f(xmin, ymin) = %v", fmin, wantF)
+			}
+		})
+	}
+}
+
+func TestHookeJeevesVsCoordinateDescent(t *testing.T) {
+	// на F2 из (1, 1) Хук–Дживс находит минимум точнее покоординатного спуска и за меньшее число
+	// вызовов f, причём ему не нужны ни границы области, ни одномерная минимизация
+	const eps = 1e-6
+	_, _, hf, hIters, err := HookeJeeves(pkg.F2, 1, 1, 0.5, 0.5, eps, 0)
+	if err != nil {
+		t.Fatalf("HookeJeeves() error = %v", err)
+	}
+	_, _, cf, cdIters := CoordinateDescent(pkg.F2, 1, 1, -4, 4, -4, 4, eps, zeroordered.GoldenSection)
+	if hf > cf {
+		t.Errorf("HookeJeeves() fmin = %v, want at most CoordinateDescent() fmin = %v", hf, cf)
+	}
+	if hIters >= cdIters {
+		t.Errorf("HookeJeeves() iters = %v, want fewer than CoordinateDescent() iters = %v", hIters, cdIters)
+	}
+}
+
+func TestPowell(t *testing.T) {
+	type args struct {
+		f    func(x, y float64) float64
+		x0   float64
+		y0   float64
+		eps  float64
+		line pkg.LineMinimizer
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantXmin  float64
+		wantYmin  float64
+		wantFmin  float64
+		wantIters int
+	}{
+		{
+			name: "Case 1: f(x,y) = x*x + math.Exp(x*x+y*y) + 4*x + 3*y",
+			args: args{
+				f:    pkg.F2,
+				x0:   1.0,
+				y0:   1.0,
+				eps:  1e-6,
+				line: zeroordered.GoldenSection,
+			},
+			wantXmin:  -0.613225,
+			wantYmin:  -0.663293,
+			wantFmin:  -1.805292,
+			wantIters: 340,
+		},
+		{
+			name: "Case 2: f(x,y) = 100*(y-x*x)^2 + (1-x)^2",
+			args: args{
+				f: func(x, y float64) float64 {
+					return 100*(y-x*x)*(y-x*x) + (1-x)*(1-x)
+				},
+				x0:   -1.2,
+				y0:   1.0,
+				eps:  1e-8,
+				line: zeroordered.Brent,
+			},
+			wantXmin:  1.0,
+			wantYmin:  1.0,
+			wantFmin:  0.0,
+			wantIters: 580,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotXmin, gotYmin, gotFmin, gotIters, err := Powell(tt.args.f, tt.args.x0, tt.args.y0, tt.args.eps, tt.args.line)
+			if err != nil {
+				t.Fatalf("Powell() error = %v", err)
+			}
+			if math.Abs(gotXmin-tt.wantXmin) > 1e-4 {
+				t.Errorf("Powell() gotXmin = %v, want %v", gotXmin, tt.wantXmin)
+			}
+			if math.Abs(gotYmin-tt.wantYmin) > 1e-4 {
+				t.Errorf("Powell() gotYmin = %v, want %v", gotYmin, tt.wantYmin)
+			}
+			if math.Abs(gotFmin-tt.wantFmin) > 1e-4 {
+				t.Errorf("Powell() gotFmin = %v, want %v", gotFmin, tt.wantFmin)
+			}
+			if gotIters != tt.wantIters {
+				t.Errorf("Powell() gotIters = %v, want %v", gotIters, tt.wantIters)
+			}
+		})
+	}
+}
+
+func TestPowellQuadratic(t *testing.T) {
+	// f(x, y) = (x + y)² + 100(x − y)²: после цикла по осям направление смещения сопряжено
+	// с оставшимся, и метод Пауэлла попадает в минимум; покоординатный спуск за то же eps
+	// к минимуму не приближается
+	f := func(x, y float64) float64 {
+		u, v := x+y, x-y
+		return u*u + 100*v*v
+	}
+	const eps = 1e-8
+	// число одномерных минимизаций: цикл — это n = 2 минимизации по направлениям набора
+	// и не больше одной вдоль нового направления
+	const n = 2
+	var searches int
+	line := pkg.LineMinimizerFunc(func(f func(x float64) float64, a, b, eps float64) pkg.Result1D {
+		searches++
+		return zeroordered.Brent.Minimize(f, a, b, eps)
+	})
+	x, y, _, _, err := Powell(f, 3, -1, eps, line)
+	if err != nil {
+		t.Fatalf("Powell() error = %v", err)
+	}
+	if math.Hypot(x, y) > 1e-6 {
+		t.Errorf("Powell() = (%v, %v), want (0, 0)", x, y)
+	}
+	// для квадратичной функции n циклов дают минимум, ещё один цикл подтверждает остановку
+	if searches > (n+1)*(n+1) {
+		t.Errorf("Powell() made %v line searches, want at most (n + 1)² = %v", searches, (n+1)*(n+1))
+	}
+	cx, cy, _, _ := CoordinateDescent(f, 3, -1, -5, 5, -5, 5, eps, zeroordered.Brent)
+	if math.Hypot(cx, cy) <= 1e-6 {
+		t.Errorf("CoordinateDescent() = (%v, %v), want it to stall away from (0, 0)", cx, cy)
+	}
+}
+
+func TestLineSearchFailure(t *testing.T) {
+	// f(x, y) = x + y не ограничена снизу: минимум вдоль антиградиента не локализуется,
+	// и методы должны сообщить об этом, а не вернуть начальную точку как решение
+	f := func(x, y float64) float64 { return x + y }
+	grad := func(x, y float64) (gx, gy float64) { return 1, 1 }
+	hess := func(x, y float64) (hxx, hxy, hyx, hyy float64) { return 1, 0, 0, 1 }
+	line := zeroordered.GoldenSection
+	methods := map[string]func() error{
+		"SteepestGradientDescent": func() error {
+			_, _, _, _, err := SteepestGradientDescent(f, grad, 1, 1, 1e-6, line, nil)
+			return err
+		},
+		"AcceleratedGradientDescent": func() error {
+			_, _, _, _, err := AcceleratedGradientDescent(f, grad, 1, 1, 2, 1e-6, line)
+			return err
+		},
+		"RavineGradientDescent": func() error {
+			_, _, _, _, err := RavineGradientDescent(f, grad, 1, 1, 0.5, 1, 1e-6, line)
+			return err
+		},
+		"NewtonModified": func() error {
+			_, _, _, _, err := NewtonModified(f, grad, hess, 1, 1, 1e-6, line, nil)
+			return err
+		},
+		"QuasiNewton": func() error {
+			_, _, _, _, err := QuasiNewton(f, grad, 1, 1, 1e-6, line, nil)
+			return err
+		},
+		"ConjGradFR": func() error {
+			_, _, _, _, err := ConjGradFR(f, grad, 1, 1, 1e-6, line, nil)
+			return err
+		},
+		"Powell": func() error {
+			_, _, _, _, err := Powell(f, 1, 1, 1e-6, line)
+			return err
+		},
+	}
+	for name, method := range methods {
+		if err := method(); !errors.Is(err, pkg.ErrNoBracket) {
+			t.Errorf("%s() error = %v, want pkg.ErrNoBracket", name, err)
+		}
+	}
+}
+
+func TestNewtonModifiedSingularHessian(t *testing.T) {
+	// f(x, y) = x⁴ + y²: в точке (0, 1) ∇f = (0, 2) ≠ 0, а H = [[0, 0], [0, 2]] вырождена —
+	// метод должен вернуть ошибку, а не паниковать
+	f := func(x, y float64) float64 { return x*x*x*x + y*y }
+	grad := func(x, y float64) (gx, gy float64) { return 4 * x * x * x, 2 * y }
+	hess := func(x, y float64) (hxx, hxy, hyx, hyy float64) { return 12 * x * x, 0, 0, 2 }
+	x, y, _, _, err := NewtonModified(f, grad, hess, 0, 1, 1e-6, zeroordered.GoldenSection, nil)
+	if !errors.Is(err, pkg.ErrSingular) {
+		t.Fatalf("NewtonModified() error = %v, want pkg.ErrSingular", err)
+	}
+	if x != 0 || y != 1 {
+		t.Errorf("NewtonModified() = (%v, %v), want the initial point (0, 1)", x, y)
+	}
+	// то же при n = 3 (направление находится методом Гаусса)
+	fn := func(x []float64) float64 { return x[0]*x[0]*x[0]*x[0] + x[1]*x[1] + x[2]*x[2] }
+	gradN := func(x, g []float64) { g[0], g[1], g[2] = 4*x[0]*x[0]*x[0], 2*x[1], 2*x[2] }
+	hessN := func(x, h []float64) {
+		clear(h)
+		h[0], h[4], h[8] = 12*x[0]*x[0], 2, 2
+	}
+	if _, _, _, err := NewtonModifiedN(fn, gradN, hessN, []float64{0, 1, 1}, 1e-6, zeroordered.GoldenSection, nil); !errors.Is(err, pkg.ErrSingular) {
+		t.Errorf("NewtonModifiedN() error = %v, want pkg.ErrSingular", err)
+	}
+}
+
+func TestFlatFunction(t *testing.T) {
+	// f(x, y) = 1 + 1e-20·(x² + y²) постоянна с точностью до округления, но ∇f ≠ 0 при gradEps = 1e-30:
+	// каждый метод должен распознать плоскую φ и остановиться, а не повторять нулевой шаг
+	f := func(x, y float64) float64 { return 1 + 1e-20*(x*x+y*y) }
+	grad := func(x, y float64) (gx, gy float64) { return 2e-20 * x, 2e-20 * y }
+	line := zeroordered.GoldenSection
+	methods := map[string]func() error{
+		"SteepestGradientDescent": func() error {
+			_, _, _, _, err := SteepestGradientDescent(f, grad, 1, 1, 1e-30, line, nil)
+			return err
+		},
+		"AcceleratedGradientDescent": func() error {
+			_, _, _, _, err := AcceleratedGradientDescent(f, grad, 1, 1, 2, 1e-30, line)
+			return err
+		},
+		"RavineGradientDescent": func() error {
+			_, _, _, _, err := RavineGradientDescent(f, grad, 1, 1, 0.5, 2, 1e-30, line)
+			return err
+		},
+	}
+	for name, method := range methods {
+		t.Run(name, func(t *testing.T) {
+			done := make(chan error, 1)
+			go func() {
+				done <- method()
+			}()
+			select {
+			case err := <-done:
+				if err != nil {
+					t.Errorf("%s() error = %v", name, err)
+				}
+			case <-time.After(3 * time.Second):
+				t.Fatalf("%s() did not terminate", name)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package multidimensional
+
+import (
+	"errors"
+	"math"
+
+	"github.com/vshulcz/edu_optimization_methods/pkg"
+)
+
+// Ошибки методов без производных.
+var (
+	// ErrBadParams — параметры метода вне допустимой области (например, shrink ∉ (0, 1)).
+	ErrBadParams = errors.New("invalid method parameters")
+	// ErrMaxEvals — число вызовов f достигло предела, а условие остановы не выполнено.
+	ErrMaxEvals = errors.New("maximum number of function evaluations exceeded")
+	// ErrMaxIter — за наибольшее число итераций условие остановы не выполнено.
+	ErrMaxIter = errors.New("maximum number of iterations exceeded")
+)
+
+// func2 представляет функцию двух переменных как функцию вектора x = (x, y).
+func func2(f func(x, y float64) float64) func(x []float64) float64 {
+	return func(x []float64) float64 {
+		return f(x[0], x[1])
+	}
+}
+
+// grad2 представляет градиент функции двух переменных в виде, который ожидают N-мерные методы.
+func grad2(grad func(x, y float64) (gx, gy float64)) func(x, g []float64) {
+	return func(x, g []float64) {
+		g[0], g[1] = grad(x[0], x[1])
+	}
+}
+
+// hess2 представляет матрицу Гессе функции двух переменных построчно: h = [hxx, hxy, hyx, hyy].
+func hess2(hess func(x, y float64) (hxx, hxy, hyx, hyy float64)) func(x, h []float64) {
+	return func(x, h []float64) {
+		h[0], h[1], h[2], h[3] = hess(x[0], x[1])
+	}
+}
+
+// norm возвращает евклидову норму x (для двух координат совпадает с math.Hypot).
+func norm(x []float64) float64 {
+	var s float64
+	for _, v := range x {
+		s = math.Hypot(s, v)
+	}
+	return s
+}
+
+// dist возвращает евклидово расстояние между x и y.
+func dist(x, y []float64) float64 {
+	var s float64
+	for i := range x {
+		s = math.Hypot(s, x[i]-y[i])
+	}
+	return s
+}
+
+// setIdentity записывает в h единичную матрицу n×n (построчно).
+func setIdentity(h []float64, n int) {
+	clear(h)
+	for i := range n {
+		h[i*n+i] = 1
+	}
+}
+
+// newtonDirection решает систему H p = –g для матрицы Гессе h (n×n, построчно).
+// При n = 2 матрица обращается явно: det = hxx*hyy – hxy*hyx.
+func newtonDirection(h, g []float64, n int) ([]float64, error) {
+	if n == 2 {
+		det := h[0]*h[3] - h[1]*h[2]
+		if math.Abs(det) < 1e-14 {
+			return nil, pkg.ErrSingular
+		}
+		return []float64{
+			-(h[3]*g[0] - h[1]*g[1]) / det,
+			-(-h[2]*g[0] + h[0]*g[1]) / det,
+		}, nil
+	}
+	b := make([]float64, n)
+	for i := range g {
+		b[i] = -g[i]
+	}
+	return pkg.SolveGauss(h, b, n)
+}
+
+// NelderMeadOptions задаёт параметры метода Нелдера–Мида.
+// Нулевые значения полей заменяются значениями по умолчанию.
+type NelderMeadOptions struct {
+	// Alpha — коэффициент отражения (по умолчанию 1).
+	Alpha float64
+	// Gamma — коэффициент растяжения, Gamma > 1 (по умолчанию 2).
+	Gamma float64
+	// Rho — коэффициент сжатия, 0 < Rho < 1 (по умолчанию 0.5).
+	Rho float64
+	// Sigma — коэффициент редукции (сжатия всего симплекса к лучшей вершине), 0 < Sigma < 1 (по умолчанию 0.5).
+	Sigma float64
+	// Adaptive — выбирать коэффициенты по размерности n (Gao, Han, 2012):
+	// Alpha = 1, Gamma = 1 + 2/n, Rho = 0.75 - 1/(2n), Sigma = 1 - 1/n (не меньше 0.5).
+	// Явно заданные коэффициенты имеют приоритет.
+	Adaptive bool
+	// Step — длина рёбер начального симплекса x0 + Step·max(|x0_i|, 1)·e_i (по умолчанию 0.1).
+	Step float64
+	// MaxIter — наибольшее число итераций (по умолчанию 1000·n).
+	MaxIter int
+	// MaxRestarts — наибольшее число перезапусков при вырождении симплекса (по умолчанию 10;
+	// отрицательное значение отключает перезапуски).
+	MaxRestarts int
+}
+
+func (o NelderMeadOptions) withDefaults(n int) NelderMeadOptions {
+	alpha, gamma, rho, sigma := 1.0, 2.0, 0.5, 0.5
+	if o.Adaptive && n > 0 {
+		dim := float64(n)
+		gamma, rho, sigma = 1+2/dim, 0.75-1/(2*dim), max(1-1/dim, 0.5)
+	}
+	if o.Alpha <= 0 {
+		o.Alpha = alpha
+	}
+	if o.Gamma <= 1 {
+		o.Gamma = gamma
+	}
+	if o.Rho <= 0 || o.Rho >= 1 {
+		o.Rho = rho
+	}
+	if o.Sigma <= 0 || o.Sigma >= 1 {
+		o.Sigma = sigma
+	}
+	if o.Step <= 0 {
+		o.Step = 0.1
+	}
+	if o.MaxIter <= 0 {
+		o.MaxIter = 1000 * n
+	}
+	if o.MaxRestarts == 0 {
+		o.MaxRestarts = 10
+	}
+	return o
+}
+
+// degenerateTol — порог нормированного объёма симплекса, ниже которого он считается вырожденным.
+const degenerateTol = 1e-10
+
+// simplexVolume возвращает нормированный объём симплекса |det(E)| / ∏‖e_i‖,
+// где e_i = x_i - x_0 — рёбра из вершины x_0. Для правильного симплекса
+// величина порядка единицы, для вырожденного (вершины в одной гиперплоскости) — нуль.
+func simplexVolume(simplex [][]float64) float64 {
+	n := len(simplex) - 1
+	E := make([]float64, n*n)
+	vol := 1.0
+	for i := range n {
+		for j := range n {
+			E[i*n+j] = simplex[i+1][j] - simplex[0][j]
+		}
+		l := norm(E[i*n : (i+1)*n])
+		if l == 0 {
+			return 0
+		}
+		vol /= l
+	}
+	// метод Гаусса с выбором главного элемента: det = ∏ диагональных элементов
+	for c := range n {
+		pivot := c
+		for r := c + 1; r < n; r++ {
+			if math.Abs(E[r*n+c]) > math.Abs(E[pivot*n+c]) {
+				pivot = r
+			}
+		}
+		if E[pivot*n+c] == 0 {
+			return 0
+		}
+		if pivot != c {
+			for k := range n {
+				E[c*n+k], E[pivot*n+k] = E[pivot*n+k], E[c*n+k]
+			}
+		}
+		vol *= E[c*n+c]
+		for r := c + 1; r < n; r++ {
+			factor := E[r*n+c] / E[c*n+c]
+			for k := c; k < n; k++ {
+				E[r*n+k] -= factor * E[c*n+k]
+			}
+		}
+	}
+	return math.Abs(vol)
+}
@@ -1,11 +1,18 @@
 package conditional
 
 import (
+	"errors"
+	"fmt"
+
 	zeroordered "github.com/vshulcz/edu_optimization_methods/internal/1_zero_ordered"
 	multidimensional "github.com/vshulcz/edu_optimization_methods/internal/3_multidimensional"
 	"github.com/vshulcz/edu_optimization_methods/pkg"
 )
 
+// ErrNoKKTPoint возвращается KuhnTucker, если ни в одном из случаев не найдена точка,
+// удовлетворяющая условиям Куна–Таккера.
+var ErrNoKKTPoint = errors.New("no Kuhn-Tucker point found")
+
 // KuhnTucker решает:
 //
 //	min f(x,y)  при  x ≥ 0, y ≥ 0
@@ -19,7 +26,7 @@ import (
 //
 // 2) Граница x=0 (только λ1 активен):
 //   - Если ∂f/∂y(0,0) = g0.y < 0, функция убывает вдоль y>0.
-//   - Решаем одномерную задачу  min_{y≥0} f(0,y) (bracket + GoldenSection).
+//   - Решаем одномерную задачу  min_{y≥0} f(0,y) (pkg.Bracket + line).
 //   - Получаем y*, проверяем λ1 = ∂f/∂x(0,y*) ≥ 0 и λ2=0.
 //
 // 3) Граница y=0 (только λ2 активен) – аналогично:
@@ -34,17 +41,20 @@ import (
 // - f: целевая функция двух переменных.
 // - grad: её градиент (gx, gy).
 // - eps: точность для одномерных и многомерных методов.
+// - line: одномерный метод (например, zeroordered.GoldenSection) для границ и поиска шага в QuasiNewton.
 //
 // Возвращает:
 // - xmin, ymin: координаты найденного минимума.
 // - fmin: значение f в этой точке.
 // - lam1Opt, lam2Opt: оптимальные множители Лагранжа.
-// - iters: число вызовов f (для оценки вычислительных затрат).func KuhnTucker(
+// - iters: число вызовов f (для оценки вычислительных затрат).
+// - err: ErrNoKKTPoint, если ни один случай не дал допустимой точки, или ошибка QuasiNewton.
 func KuhnTucker(
 	f func(x, y float64) float64,
 	grad func(x, y float64) (gx, gy float64),
 	eps float64,
-) (xmin, ymin, fmin, lam1Opt, lam2Opt float64, iters int) {
+	line pkg.LineMinimizer,
+) (xmin, ymin, fmin, lam1Opt, lam2Opt float64, iters int, err error) {
 	phiF := func(x, y float64) float64 {
 		iters++
 		return f(x, y)
@@ -53,42 +63,62 @@ func KuhnTucker(
 	// проверка угла (0,0)
 	gx0, gy0 := grad(0, 0)
 	if gx0 >= 0 && gy0 >= 0 {
-		return 0, 0, phiF(0, 0), gx0, gy0, iters
+		return 0, 0, phiF(0, 0), gx0, gy0, iters, nil
 	}
 
+	// ошибки локализации минимума на границах: если ни один случай не даст ответа,
+	// они объясняют, почему
+	var boundaryErr error
+
 	// граница x=0, φ(y)=f(0,y), φ'(0)=gy0
 	if gy0 < 0 {
 		phiY := func(y float64) float64 { return phiF(0, y) }
-		a, b := pkg.BracketMinimum(phiY)
-		y1, fy1, _ := zeroordered.GoldenSectionSearch(phiY, a, b, eps)
-		if y1 >= 0 {
-			gx1, _ := grad(0, y1)
-			if gx1 >= 0 {
-				return 0, y1, fy1, gx1, 0, iters
+		a, _, b, err := pkg.Bracket(phiY, 0, pkg.BracketStep, pkg.BracketGrow)
+		if err == nil {
+			res := line.Minimize(phiY, a, b, eps)
+			y1, fy1 := res.Xmin, res.Fmin
+			if y1 >= 0 {
+				gx1, _ := grad(0, y1)
+				if gx1 >= 0 {
+					return 0, y1, fy1, gx1, 0, iters, nil
+				}
 			}
+		} else {
+			boundaryErr = errors.Join(boundaryErr, fmt.Errorf("boundary x = 0: %w", err))
 		}
 	}
 
 	// граница y=0, φ(x)=f(x,0), φ'(0)=gx0
 	if gx0 < 0 {
 		phiX := func(x float64) float64 { return phiF(x, 0) }
-		a, b := pkg.BracketMinimum(phiX)
-		x1, fx1, _ := zeroordered.GoldenSectionSearch(phiX, a, b, eps)
-		if x1 >= 0 {
-			_, gy1 := grad(x1, 0)
-			if gy1 >= 0 {
-				return x1, 0, fx1, 0, gy1, iters
+		a, _, b, err := pkg.Bracket(phiX, 0, pkg.BracketStep, pkg.BracketGrow)
+		if err == nil {
+			res := line.Minimize(phiX, a, b, eps)
+			x1, fx1 := res.Xmin, res.Fmin
+			if x1 >= 0 {
+				_, gy1 := grad(x1, 0)
+				if gy1 >= 0 {
+					return x1, 0, fx1, 0, gy1, iters, nil
+				}
 			}
+		} else {
+			boundaryErr = errors.Join(boundaryErr, fmt.Errorf("boundary y = 0: %w", err))
 		}
 	}
 
 	// gx0<0 и gy0<0
-	x0, y0, f0, _ := multidimensional.QuasiNewton(phiF, grad, 0, 0, eps)
+	x0, y0, f0, _, err := multidimensional.QuasiNewton(phiF, grad, 0, 0, eps, line, nil)
+	if err != nil {
+		return x0, y0, f0, 0, 0, iters, fmt.Errorf("interior: %w", err)
+	}
 	if x0 >= 0 && y0 >= 0 {
-		return x0, y0, f0, 0, 0, iters
+		return x0, y0, f0, 0, 0, iters, nil
 	}
 
-	return
+	if boundaryErr != nil {
+		return 0, 0, 0, 0, 0, iters, fmt.Errorf("%w: %w", ErrNoKKTPoint, boundaryErr)
+	}
+	return 0, 0, 0, 0, 0, iters, ErrNoKKTPoint
 }
 
 // ExternalPenalty решает задачу
@@ -123,13 +153,14 @@ func KuhnTucker(
 // - fmin       : f(xmin,ymin).
 // - r          : финальный коэффициент штрафа.
 // - outerIt    : сколько раз обновляли r.
+// - err        : ошибка SteepestGradientDescent, если внутренняя минимизация не удалась.
 func ExternalPenalty(
 	f func(x, y float64) float64,
 	grad func(x, y float64) (gx, gy float64),
 	x0, y0 float64,
 	r0, rFactor, epsConstr, epsGrad float64,
 	maxOuterIter int,
-) (xmin, ymin, fmin, r float64, outerIt int) {
+) (xmin, ymin, fmin, r float64, outerIt int, err error) {
 	// Штрафная функция H(x,y) = [max(0, -x)]^2 + [max(0, -y)]^2,
 	// где g1(x)= -x <= 0  эквивалентно x >= 0, g2(y)= -y <= 0
 	H := func(x, y float64) float64 {
@@ -167,12 +198,17 @@ func ExternalPenalty(
 			return dfx + r*hx, dfy + r*hy
 		}
 
-		xNew, yNew, _, _ := multidimensional.SteepestGradientDescent(
+		xNew, yNew, _, _, err := multidimensional.SteepestGradientDescent(
 			phiF, gradPhiF,
 			xmin, ymin,
 			epsGrad,
+			zeroordered.GoldenSection,
+			nil,
 		)
 		xmin, ymin = xNew, yNew
+		if err != nil {
+			return xmin, ymin, f(xmin, ymin), r, outerIt, fmt.Errorf("penalty r = %g: %w", r, err)
+		}
 
 		// Допустимость H(x) ≤ ε
 		if H(xmin, ymin) <= epsConstr {
@@ -183,5 +219,5 @@ func ExternalPenalty(
 	}
 
 	fmin = f(xmin, ymin)
-	return xmin, ymin, fmin, r, outerIt
+	return xmin, ymin, fmin, r, outerIt, nil
 }
@@ -1,8 +1,12 @@
 package conditional
 
 import (
+	"errors"
 	"math"
 	"testing"
+
+	zeroordered "github.com/vshulcz/edu_optimization_methods/internal/1_zero_ordered"
+	"github.com/vshulcz/edu_optimization_methods/pkg"
 )
 
 func TestKuhnTucker(t *testing.T) {
@@ -37,7 +41,7 @@ func TestKuhnTucker(t *testing.T) {
 			wantFmin:    -81.0,
 			wantLam1Opt: 0.0,
 			wantLam2Opt: 4.0,
-			wantIters:   40,
+			wantIters:   39,
 		},
 		{
 			name: "F: x²+y² on x>=0,y>=0",
@@ -60,7 +64,10 @@ func TestKuhnTucker(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotXmin, gotYmin, gotFmin, gotLam1Opt, gotLam2Opt, gotIters := KuhnTucker(tt.args.f, tt.args.grad, tt.args.gradEps)
+			gotXmin, gotYmin, gotFmin, gotLam1Opt, gotLam2Opt, gotIters, err := KuhnTucker(tt.args.f, tt.args.grad, tt.args.gradEps, zeroordered.GoldenSection)
+			if err != nil {
+				t.Fatalf("KuhnTucker() error = %v", err)
+			}
 			if !equal(gotXmin, tt.wantXmin, tt.args.gradEps) {
 				t.Errorf("KuhnTucker() gotXmin = %v, want %v", gotXmin, tt.wantXmin)
 			}
@@ -83,6 +90,17 @@ func TestKuhnTucker(t *testing.T) {
 	}
 }
 
+func TestKuhnTuckerUnbounded(t *testing.T) {
+	// f(x, y) = x - y не ограничена снизу на x ≥ 0, y ≥ 0: вдоль границы x = 0
+	// минимум не локализуется, и решения нет
+	f := func(x, y float64) float64 { return x - y }
+	grad := func(x, y float64) (gx, gy float64) { return 1, -1 }
+	_, _, _, _, _, _, err := KuhnTucker(f, grad, 1e-6, zeroordered.GoldenSection)
+	if !errors.Is(err, pkg.ErrNoBracket) {
+		t.Errorf("KuhnTucker() error = %v, want pkg.ErrNoBracket", err)
+	}
+}
+
 func equal(a, b, tol float64) bool {
 	return math.Abs(a-b) <= tol
 }
@@ -160,7 +178,10 @@ func TestExternalPenalty(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotXmin, gotYmin, gotFmin, gotR, gotOuterIt := ExternalPenalty(tt.args.f, tt.args.grad, tt.args.x0, tt.args.y0, tt.args.r0, tt.args.rFactor, tt.args.epsConstr, tt.args.epsGrad, tt.args.maxOuterIter)
+			gotXmin, gotYmin, gotFmin, gotR, gotOuterIt, err := ExternalPenalty(tt.args.f, tt.args.grad, tt.args.x0, tt.args.y0, tt.args.r0, tt.args.rFactor, tt.args.epsConstr, tt.args.epsGrad, tt.args.maxOuterIter)
+			if err != nil {
+				t.Fatalf("ExternalPenalty() error = %v", err)
+			}
 			if !equal(gotXmin, tt.wantXmin, tt.args.epsGrad) {
 				t.Errorf("ExternalPenalty() gotXmin = %v, want %v", gotXmin, tt.wantXmin)
 			}
@@ -8,6 +8,9 @@ import (
 	multidimensional "github.com/vshulcz/edu_optimization_methods/internal/3_multidimensional"
 	conditional "github.com/vshulcz/edu_optimization_methods/internal/4_conditional"
 	"github.com/vshulcz/edu_optimization_methods/pkg"
+	"github.com/vshulcz/edu_optimization_methods/pkg/autodiff"
+	"github.com/vshulcz/edu_optimization_methods/pkg/autodiff/reverse"
+	"github.com/vshulcz/edu_optimization_methods/pkg/numdiff"
 )
 
 const (
@@ -18,90 +21,136 @@ const (
 )
 
 func main() {
-	xmin, fmin, iterations := zeroordered.PassiveSearch(pkg.F1, a, b, epsilon)
-	fmt.Printf("Метод пассивного поиска:\n")
-	fmt.Printf("Минимум функции: x = %v, f(x) = %v\n", xmin, fmin)
-	fmt.Printf("Гарантированный интервал для истинного минимума: [%f, %f]\n", xmin-epsilon, xmin+epsilon)
-	fmt.Printf("Количество итераций: %d\n\n", iterations)
-
-	xmin, fmin, aFinal, bFinal, iterations := zeroordered.DichotomySearch(pkg.F1, a, b, epsilon, delta)
-	fmt.Printf("Метод дихотомии:\n")
-	fmt.Printf("Минимум найден в точке x = %f, f(x) = %f\n", xmin, fmin)
-	fmt.Printf("Локализующий интервал: [%f, %f]\n", aFinal, bFinal)
-	fmt.Printf("Количество итераций: %d\n\n", iterations)
-
-	xmin, fmin, iterations = zeroordered.GoldenSectionSearch(pkg.F1, a, b, epsilon)
-	fmt.Printf("Метод золотого сечения:\n")
-	fmt.Printf("Минимум найден в точке x = %f, f(x) = %f\n", xmin, fmin)
-	fmt.Printf("Количество итераций: %d\n\n", iterations)
-
-	xmin, fmin, iterations = zeroordered.FibonacciSearch(pkg.F1, a, b, epsilon)
-	fmt.Printf("Метод Фибоначчи:\n")
-	fmt.Printf("Минимум найден в точке x = %f, f(x) = %f\n", xmin, fmin)
+	// предварительная проверка аналитических производных
+	if err := numdiff.Check1D(pkg.F1, pkg.DF1, pkg.DDF1, []float64{a, (a + b) / 2, b}).Validate(1e-6); err != nil {
+		fmt.Printf("F1: %v\n", err)
+		return
+	}
+	if err := numdiff.Check2D(pkg.F2, pkg.GradF2, pkg.HessF2, [][2]float64{{0, 0}, {1, 1}, {-1, 0.5}}).Validate(1e-6); err != nil {
+		fmt.Printf("F2: %v\n", err)
+		return
+	}
+
+	printResult1D("Метод пассивного поиска", zeroordered.PassiveSearch(pkg.F1, a, b, epsilon))
+
+	res, lowerBound := zeroordered.PiyavskiiSearch(pkg.F1, a, b, 0, epsilon)
+	printResult1D(fmt.Sprintf("Метод ломаных Пиявского (нижняя граница минимума %f)", lowerBound), res)
+
+	printResult1D("Метод дихотомии", zeroordered.DichotomySearch(pkg.F1, a, b, epsilon, delta))
+	printResult1D("Метод золотого сечения", zeroordered.GoldenSectionSearch(pkg.F1, a, b, epsilon))
+	printResult1D("Метод Фибоначчи", zeroordered.FibonacciSearch(pkg.F1, a, b, epsilon))
+	printResult1D("Метод парабол", zeroordered.ParabolicSearch(pkg.F1, a, b, epsilon))
+	printResult1D("Метод Брента", zeroordered.BrentSearch(pkg.F1, a, b, epsilon))
+	// трасса приближений: по ней оценивается порядок сходимости
+	var tr pkg.Trace
+	res, err := highordered.TangentSearch(pkg.F1, pkg.DF1, a, b, epsilon, highordered.DefaultMaxIter, &tr)
+	printResult1DErr("Метод касательных, "+convergence(tr), res, err)
+	tr = nil
+	res, err = highordered.NewtonSearch(pkg.F1, pkg.DF1, pkg.DDF1, a, epsilon, highordered.DefaultMaxIter, &tr)
+	printResult1DErr("Метод Ньютона-Рафсона, "+convergence(tr), res, err)
+	// те же производные F1, полученные автоматическим дифференцированием
+	f1, df1, d2f1 := autodiff.Derivatives(func(x autodiff.Dual) autodiff.Dual { return x.Add(x.Mul(x).Inv()) })
+	res, err = highordered.NewtonSearch(f1, df1, d2f1, a, epsilon, highordered.DefaultMaxIter, nil)
+	printResult1DErr("Метод Ньютона-Рафсона (autodiff)", res, err)
+	ndf1, nd2f1 := numdiff.Derivatives(pkg.F1)
+	res, err = highordered.NewtonSearch(pkg.F1, ndf1, nd2f1, a, epsilon, highordered.DefaultMaxIter, nil)
+	printResult1DErr("Метод Ньютона-Рафсона (numdiff)", res, err)
+	res, err = highordered.SafeguardedNewtonSearch(pkg.F1, pkg.DF1, pkg.DDF1, a, b, epsilon, highordered.DefaultMaxIter)
+	printResult1DErr("Метод Ньютона с защитой", res, err)
+	res, err = highordered.HalleySearch(pkg.F1, pkg.DF1, pkg.DDF1, pkg.D3F1, a, epsilon, highordered.DefaultMaxIter, nil)
+	printResult1DErr("Метод Галлея", res, err)
+	res, err = highordered.ChebyshevSearch(pkg.F1, pkg.DF1, pkg.DDF1, pkg.D3F1, a, epsilon, highordered.DefaultMaxIter, nil)
+	printResult1DErr("Метод Чебышёва", res, err)
+	tr = nil
+	res, err = highordered.SecantSearch(pkg.F1, pkg.DF1, a, b, epsilon, highordered.DefaultMaxIter, &tr)
+	printResult1DErr("Метод секущих, "+convergence(tr), res, err)
+	res, err = highordered.SecantSearch(pkg.F1, numdiff.ComplexDerivative(func(z complex128) complex128 { return z + 1/(z*z) }), a, b, epsilon, highordered.DefaultMaxIter, nil)
+	printResult1DErr("Метод секущих (complex step)", res, err)
+	res, err = highordered.IllinoisSearch(pkg.F1, pkg.DF1, a, b, epsilon, highordered.DefaultMaxIter)
+	printResult1DErr("Метод Illinois", res, err)
+	res, err = highordered.AndersonBjorckSearch(pkg.F1, pkg.DF1, a, b, epsilon, highordered.DefaultMaxIter)
+	printResult1DErr("Метод Андерсона-Бьорка", res, err)
+	printResult1D("Метод кубической интерполяции", highordered.CubicSearch(pkg.F1, pkg.DF1, a, b, epsilon))
+
+	xmin, ymin, fmin, iterations := multidimensional.CoordinateDescent(pkg.F2, 1, 1, -4, 4, -4, 4, epsilon, zeroordered.GoldenSection)
+	fmt.Printf("Метод покоординатного спуска:\n")
+	fmt.Printf("Минимум найден в точке (x,y) = (%f, %f), f(x,y) = %f\n", xmin, ymin, fmin)
 	fmt.Printf("Количество итераций: %d\n\n", iterations)
 
-	xmin, fmin, iterations = highordered.TangentSearch(pkg.F1, pkg.DF1, a, b, epsilon)
-	fmt.Printf("Метод касательных:\n")
-	fmt.Printf("Минимум найден в точке x = %f, f(x) = %f\n", xmin, fmin)
+	xmin, ymin, fmin, iterations, err = multidimensional.HookeJeeves(pkg.F2, 1, 1, 0.5, 0.5, epsilon, 0)
+	fmt.Printf("%s:\n", withErr("Метод Хука-Дживса", err))
+	fmt.Printf("Минимум найден в точке (x,y) = (%f, %f), f(x,y) = %f\n", xmin, ymin, fmin)
 	fmt.Printf("Количество итераций: %d\n\n", iterations)
 
-	xmin, fmin, iterations = highordered.NewtonSearch(pkg.F1, pkg.DF1, pkg.DDF1, a, epsilon)
-	fmt.Printf("Метод Ньютона-Рафсона:\n")
-	fmt.Printf("Минимум найден в точке x = %f, f(x) = %f\n", xmin, fmin)
+	xmin, ymin, fmin, iterations = multidimensional.GradientDescentBacktracking(pkg.F2, pkg.GradF2, 0, 0, 1.0, epsilon, 0.5, 1e-4)
+	fmt.Printf("Градиентный метод с дроблением шага:\n")
+	fmt.Printf("Минимум найден в точке (x,y) = (%f, %f), f(x,y) = %f\n", xmin, ymin, fmin)
 	fmt.Printf("Количество итераций: %d\n\n", iterations)
 
-	xmin, fmin, iterations = highordered.SecantSearch(pkg.F1, pkg.DF1, a, b, epsilon)
-	fmt.Printf("Метод секущих:\n")
-	fmt.Printf("Минимум найден в точке x = %f, f(x) = %f\n", xmin, fmin)
+	tr = nil
+	xmin, ymin, fmin, iterations, err = multidimensional.SteepestGradientDescent(pkg.F2, pkg.GradF2, 0, 0, epsilon, zeroordered.GoldenSection, &tr)
+	fmt.Printf("%s:\n", withErr("Метод наискорейшего градиентного спуска", err))
+	fmt.Printf("Минимум найден в точке (x,y) = (%f, %f), f(x,y) = %f\n", xmin, ymin, fmin)
+	fmt.Printf("Эмпирический %s\n", convergence(tr))
 	fmt.Printf("Количество итераций: %d\n\n", iterations)
 
-	xmin, ymin, fmin, iterations := multidimensional.CoordinateDescent(pkg.F2, 1, 1, -4, 4, -4, 4, epsilon)
-	fmt.Printf("Метод покоординатного спуска:\n")
+	xmin, ymin, fmin, iterations, err = multidimensional.AcceleratedGradientDescent(pkg.F2, pkg.GradF2, 0, 0, 2, epsilon, zeroordered.GoldenSection)
+	fmt.Printf("%s:\n", withErr("Ускоренный градиентный метод p-го порядка", err))
 	fmt.Printf("Минимум найден в точке (x,y) = (%f, %f), f(x,y) = %f\n", xmin, ymin, fmin)
 	fmt.Printf("Количество итераций: %d\n\n", iterations)
 
-	xmin, ymin, fmin, iterations = multidimensional.GradientDescentBacktracking(pkg.F2, pkg.GradF2, 0, 0, 1.0, epsilon, 0.5, 1e-4)
-	fmt.Printf("Градиентный метод с дроблением шага:\n")
+	xmin, ymin, fmin, iterations, err = multidimensional.RavineGradientDescent(pkg.F2, pkg.GradF2, 0, 0, 0.5, 1, epsilon, zeroordered.GoldenSection)
+	fmt.Printf("%s:\n", withErr("Овражный метод", err))
 	fmt.Printf("Минимум найден в точке (x,y) = (%f, %f), f(x,y) = %f\n", xmin, ymin, fmin)
 	fmt.Printf("Количество итераций: %d\n\n", iterations)
 
-	xmin, ymin, fmin, iterations = multidimensional.SteepestGradientDescent(pkg.F2, pkg.GradF2, 0, 0, epsilon)
-	fmt.Printf("Метод наискорейшего градиентного спуска:\n")
+	tr = nil
+	xmin, ymin, fmin, iterations, err = multidimensional.NewtonModified(pkg.F2, pkg.GradF2, pkg.HessF2, 0, 0, epsilon, zeroordered.GoldenSection, &tr)
+	fmt.Printf("%s:\n", withErr("Модифицированный метод Ньютона", err))
 	fmt.Printf("Минимум найден в точке (x,y) = (%f, %f), f(x,y) = %f\n", xmin, ymin, fmin)
+	fmt.Printf("Эмпирический %s\n", convergence(tr))
 	fmt.Printf("Количество итераций: %d\n\n", iterations)
 
-	xmin, ymin, fmin, iterations = multidimensional.AcceleratedGradientDescent(pkg.F2, pkg.GradF2, 0, 0, 2, epsilon)
-	fmt.Printf("Ускоренный градиентный метод p-го порядка:\n")
+	tr = nil
+	xmin, ymin, fmin, iterations, err = multidimensional.QuasiNewton(pkg.F2, pkg.GradF2, 0, 0, epsilon, zeroordered.GoldenSection, &tr)
+	fmt.Printf("%s:\n", withErr("Квазиньютоновский метод", err))
 	fmt.Printf("Минимум найден в точке (x,y) = (%f, %f), f(x,y) = %f\n", xmin, ymin, fmin)
+	fmt.Printf("Эмпирический %s\n", convergence(tr))
 	fmt.Printf("Количество итераций: %d\n\n", iterations)
 
-	xmin, ymin, fmin, iterations = multidimensional.RavineGradientDescent(pkg.F2, pkg.GradF2, 0, 0, 0.5, 1, epsilon)
-	fmt.Printf("Овражный метод:\n")
+	// градиент F2, полученный обратным режимом автоматического дифференцирования
+	gradF2 := reverse.Grad2(func(x, y reverse.Value) reverse.Value {
+		return reverse.Sum(x.Mul(x), reverse.Exp(x.Mul(x).Add(y.Mul(y))), x.MulConst(4), y.MulConst(3))
+	})
+	xmin, ymin, fmin, iterations, err = multidimensional.QuasiNewton(pkg.F2, gradF2, 0, 0, epsilon, zeroordered.GoldenSection, nil)
+	fmt.Printf("%s:\n", withErr("Квазиньютоновский метод (градиент через reverse)", err))
 	fmt.Printf("Минимум найден в точке (x,y) = (%f, %f), f(x,y) = %f\n", xmin, ymin, fmin)
 	fmt.Printf("Количество итераций: %d\n\n", iterations)
 
-	xmin, ymin, fmin, iterations = multidimensional.NewtonModified(pkg.F2, pkg.GradF2, pkg.HessF2, 0, 0, epsilon)
-	fmt.Printf("Модифицированный метод Ньютона:\n")
+	tr = nil
+	xmin, ymin, fmin, iterations, err = multidimensional.ConjGradFR(pkg.F2, pkg.GradF2, 0, 0, epsilon, zeroordered.GoldenSection, &tr)
+	fmt.Printf("%s:\n", withErr("Метод сопряженных отрезков", err))
 	fmt.Printf("Минимум найден в точке (x,y) = (%f, %f), f(x,y) = %f\n", xmin, ymin, fmin)
+	fmt.Printf("Эмпирический %s\n", convergence(tr))
 	fmt.Printf("Количество итераций: %d\n\n", iterations)
 
-	xmin, ymin, fmin, iterations = multidimensional.QuasiNewton(pkg.F2, pkg.GradF2, 0, 0, epsilon)
-	fmt.Printf("Квазиньютоновский метод:\n")
+	xmin, ymin, fmin, iterations, err = multidimensional.NelderMead(pkg.F2, 0, 0, epsilon, multidimensional.NelderMeadOptions{})
+	fmt.Printf("%s:\n", withErr("Метод Нелдера-Мида", err))
 	fmt.Printf("Минимум найден в точке (x,y) = (%f, %f), f(x,y) = %f\n", xmin, ymin, fmin)
 	fmt.Printf("Количество итераций: %d\n\n", iterations)
 
-	xmin, ymin, fmin, iterations = multidimensional.ConjGradFR(pkg.F2, pkg.GradF2, 0, 0, epsilon)
-	fmt.Printf("Метод сопряженных отрезков:\n")
+	xmin, ymin, fmin, iterations, err = multidimensional.Powell(pkg.F2, 1, 1, epsilon, zeroordered.GoldenSection)
+	fmt.Printf("%s:\n", withErr("Метод Пауэлла", err))
 	fmt.Printf("Минимум найден в точке (x,y) = (%f, %f), f(x,y) = %f\n", xmin, ymin, fmin)
 	fmt.Printf("Количество итераций: %d\n\n", iterations)
 
-	xmin, ymin, fmin, l1, l2, iterations := conditional.KuhnTucker(pkg.F3, pkg.GradF3, epsilon)
-	fmt.Printf("Метод Куна-Таккера:\n")
+	xmin, ymin, fmin, l1, l2, iterations, err := conditional.KuhnTucker(pkg.F3, pkg.GradF3, epsilon, zeroordered.GoldenSection)
+	fmt.Printf("%s:\n", withErr("Метод Куна-Таккера", err))
 	fmt.Printf("Минимум найден в точке (x,y) = (%f, %f), f(x,y) = %f\n", xmin, ymin, fmin)
 	fmt.Printf("Найденные лямбды %f, %f\n", l1, l2)
 	fmt.Printf("Количество итераций: %d\n\n", iterations)
 
-	xmin, ymin, fmin, r, outerIt := conditional.ExternalPenalty(
+	xmin, ymin, fmin, r, outerIt, err := conditional.ExternalPenalty(
 		pkg.F3, pkg.GradF3,
 		0, 0,
 		1,
@@ -110,7 +159,38 @@ func main() {
 		0.001,
 		10,
 	)
-	fmt.Printf("Метод Внешних штрафов:\n")
+	fmt.Printf("%s:\n", withErr("Метод Внешних штрафов", err))
 	fmt.Printf("Минимум найден в точке (x,y) = (%f, %f), f(x,y) = %f, (r=%f)\n", xmin, ymin, fmin, r)
 	fmt.Printf("Количество итераций: %d\n\n", outerIt)
 }
+
+func printResult1D(name string, res pkg.Result1D) {
+	fmt.Printf("%s:\n", name)
+	fmt.Printf("Минимум найден в точке x = %f, f(x) = %f\n", res.Xmin, res.Fmin)
+	fmt.Printf("Локализующий интервал: [%f, %f]\n", res.AFinal, res.BFinal)
+	fmt.Printf("Количество итераций: %d\n", res.Iters)
+	fmt.Printf("Вычислений f, f', f'', f''': %d, %d, %d, %d\n", res.FEvals, res.DFEvals, res.D2FEvals, res.D3FEvals)
+	fmt.Printf("Причина остановки: %v\n\n", res.Reason)
+}
+
+// convergence возвращает эмпирические порядок и константу сходимости по трассе приближений.
+func convergence(tr pkg.Trace) string {
+	order, rate, err := pkg.EstimateConvergence(tr.Steps())
+	if err != nil {
+		return "порядок сходимости не оценён"
+	}
+	return fmt.Sprintf("порядок сходимости p = %.2f, C = %.3g", order, rate)
+}
+
+// printResult1DErr печатает результат метода, который может не сойтись, вместе с ошибкой.
+func printResult1DErr(name string, res pkg.Result1D, err error) {
+	printResult1D(withErr(name, err), res)
+}
+
+// withErr дописывает к названию метода ошибку, если он не сошёлся.
+func withErr(name string, err error) string {
+	if err != nil {
+		return fmt.Sprintf("%s (ошибка: %v)", name, err)
+	}
+	return name
+}
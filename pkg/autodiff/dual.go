@@ -0,0 +1,227 @@
+// Package autodiff реализует автоматическое дифференцирование целевых функций.
+//
+// Прямой режим построен на гипердуальных числах: целевая функция записывается
+// один раз через тип Dual, а производные первого и второго порядка,
+// градиент и матрица Гессе получаются без ручного вывода формул.
+package autodiff
+
+import "math"
+
+// Dual — гипердуальное число V + D1·ε1 + D2·ε2 + D12·ε1ε2, где ε1² = ε2² = 0, ε1ε2 ≠ 0.
+//
+// Если в аргументе x функции f положить D1 = e_i, D2 = e_j, то в результате
+// V = f(x), D1 = ∂f/∂x_i, D2 = ∂f/∂x_j, D12 = ∂²f/∂x_i∂x_j — без погрешности усечения.
+type Dual struct {
+	V, D1, D2, D12 float64
+}
+
+// Const возвращает константу c (все производные равны нулю).
+func Const(c float64) Dual {
+	return Dual{V: c}
+}
+
+// Var возвращает независимую переменную x одномерной функции:
+// f(Var(x)) содержит f(x), f'(x) (в D1 и D2) и f”(x) (в D12).
+func Var(x float64) Dual {
+	return Dual{V: x, D1: 1, D2: 1}
+}
+
+// Add возвращает x + y.
+func (x Dual) Add(y Dual) Dual {
+	return Dual{x.V + y.V, x.D1 + y.D1, x.D2 + y.D2, x.D12 + y.D12}
+}
+
+// Sub возвращает x - y.
+func (x Dual) Sub(y Dual) Dual {
+	return Dual{x.V - y.V, x.D1 - y.D1, x.D2 - y.D2, x.D12 - y.D12}
+}
+
+// Mul возвращает x · y.
+func (x Dual) Mul(y Dual) Dual {
+	return Dual{
+		V:   x.V * y.V,
+		D1:  x.V*y.D1 + x.D1*y.V,
+		D2:  x.V*y.D2 + x.D2*y.V,
+		D12: x.V*y.D12 + x.D1*y.D2 + x.D2*y.D1 + x.D12*y.V,
+	}
+}
+
+// Div возвращает x / y.
+func (x Dual) Div(y Dual) Dual {
+	return x.Mul(y.Inv())
+}
+
+// Neg возвращает -x.
+func (x Dual) Neg() Dual {
+	return Dual{-x.V, -x.D1, -x.D2, -x.D12}
+}
+
+// Inv возвращает 1 / x.
+func (x Dual) Inv() Dual {
+	inv := 1 / x.V
+	return x.chain(inv, -inv*inv, 2*inv*inv*inv)
+}
+
+// AddConst возвращает x + c.
+func (x Dual) AddConst(c float64) Dual {
+	x.V += c
+	return x
+}
+
+// MulConst возвращает c · x.
+func (x Dual) MulConst(c float64) Dual {
+	return Dual{c * x.V, c * x.D1, c * x.D2, c * x.D12}
+}
+
+// chain применяет к x скалярную функцию g, для которой g(x.V) = g0, g'(x.V) = g1, g”(x.V) = g2.
+func (x Dual) chain(g0, g1, g2 float64) Dual {
+	return Dual{
+		V:   g0,
+		D1:  g1 * x.D1,
+		D2:  g1 * x.D2,
+		D12: g1*x.D12 + g2*x.D1*x.D2,
+	}
+}
+
+// Exp возвращает e^x.
+func Exp(x Dual) Dual {
+	e := math.Exp(x.V)
+	return x.chain(e, e, e)
+}
+
+// Log возвращает натуральный логарифм x.
+func Log(x Dual) Dual {
+	return x.chain(math.Log(x.V), 1/x.V, -1/(x.V*x.V))
+}
+
+// Sin возвращает sin x.
+func Sin(x Dual) Dual {
+	s, c := math.Sincos(x.V)
+	return x.chain(s, c, -s)
+}
+
+// Cos возвращает cos x.
+func Cos(x Dual) Dual {
+	s, c := math.Sincos(x.V)
+	return x.chain(c, -s, -c)
+}
+
+// Sqrt возвращает √x.
+func Sqrt(x Dual) Dual {
+	r := math.Sqrt(x.V)
+	return x.chain(r, 0.5/r, -0.25/(r*x.V))
+}
+
+// Pow возвращает x^p для вещественной степени p.
+func Pow(x Dual, p float64) Dual {
+	return x.chain(math.Pow(x.V, p), p*math.Pow(x.V, p-1), p*(p-1)*math.Pow(x.V, p-2))
+}
+
+// Abs возвращает |x|; в нуле производная считается равной нулю.
+func Abs(x Dual) Dual {
+	switch {
+	case x.V > 0:
+		return x
+	case x.V < 0:
+		return x.Neg()
+	}
+	return Const(0)
+}
+
+// Derivatives по функции одной переменной, записанной через Dual, строит
+// f, f' и f” в виде, который ожидают методы highordered (TangentSearch, NewtonSearch и др.).
+func Derivatives(f func(x Dual) Dual) (fv, df, d2f func(x float64) float64) {
+	fv = func(x float64) float64 {
+		return f(Const(x)).V
+	}
+	df = func(x float64) float64 {
+		return f(Dual{V: x, D1: 1}).D1
+	}
+	d2f = func(x float64) float64 {
+		return f(Var(x)).D12
+	}
+	return
+}
+
+// Func2 возвращает значение функции двух переменных, записанной через Dual.
+func Func2(f func(x, y Dual) Dual) func(x, y float64) float64 {
+	return func(x, y float64) float64 {
+		return f(Const(x), Const(y)).V
+	}
+}
+
+// Grad2 строит градиент функции двух переменных в виде,
+// который ожидают методы multidimensional (например, SteepestGradientDescent).
+// Градиент вычисляется за один проход: ε1 отвечает за x, ε2 — за y.
+func Grad2(f func(x, y Dual) Dual) func(x, y float64) (gx, gy float64) {
+	return func(x, y float64) (gx, gy float64) {
+		r := f(Dual{V: x, D1: 1}, Dual{V: y, D2: 1})
+		return r.D1, r.D2
+	}
+}
+
+// Hess2 строит матрицу Гессе функции двух переменных в виде,
+// который ожидает multidimensional.NewtonModified.
+func Hess2(f func(x, y Dual) Dual) func(x, y float64) (hxx, hxy, hyx, hyy float64) {
+	return func(x, y float64) (hxx, hxy, hyx, hyy float64) {
+		hxx = f(Var(x), Const(y)).D12
+		hxy = f(Dual{V: x, D1: 1}, Dual{V: y, D2: 1}).D12
+		hyy = f(Const(x), Var(y)).D12
+		return hxx, hxy, hxy, hyy
+	}
+}
+
+// FuncN строит функцию n переменных в виде, который ожидают методы multidimensional с суффиксом N.
+func FuncN(f func(x []Dual) Dual) func(x []float64) float64 {
+	var z []Dual
+	return func(x []float64) float64 {
+		z = seed(z, x, -1, -1)
+		return f(z).V
+	}
+}
+
+// GradN строит градиент функции n переменных в виде, который ожидают методы multidimensional
+// с суффиксом N (QuasiNewtonN, ConjGradFRN и др.): grad записывает ∇f(x) в g.
+// Требует n проходов f: в i-м ε1 отвечает за x_i.
+func GradN(f func(x []Dual) Dual) func(x, g []float64) {
+	var z []Dual
+	return func(x, g []float64) {
+		for i := range x {
+			z = seed(z, x, i, -1)
+			g[i] = f(z).D1
+		}
+	}
+}
+
+// HessN строит матрицу Гессе функции n переменных в виде, который ожидает
+// multidimensional.NewtonModifiedN: hess записывает её в h построчно (h[i*n+j] = ∂²f/∂x_i∂x_j).
+// Требует n(n + 1)/2 проходов f: ε1 отвечает за x_i, ε2 — за x_j, j ≥ i.
+func HessN(f func(x []Dual) Dual) func(x, h []float64) {
+	var z []Dual
+	return func(x, h []float64) {
+		n := len(x)
+		for i := range n {
+			for j := i; j < n; j++ {
+				z = seed(z, x, i, j)
+				h[i*n+j] = f(z).D12
+				h[j*n+i] = h[i*n+j]
+			}
+		}
+	}
+}
+
+// seed записывает в z (переиспользуя его память) точку x, в которой D1 = 1 у x_i, а D2 = 1 у x_j
+// (i или j, равные -1, означают, что соответствующее направление не задано).
+func seed(z []Dual, x []float64, i, j int) []Dual {
+	z = z[:0]
+	for k, v := range x {
+		z = append(z, Const(v))
+		if k == i {
+			z[k].D1 = 1
+		}
+		if k == j {
+			z[k].D2 = 1
+		}
+	}
+	return z
+}
@@ -0,0 +1,114 @@
+package autodiff
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/vshulcz/edu_optimization_methods/pkg"
+)
+
+func TestDerivatives(t *testing.T) {
+	tests := []struct {
+		name        string
+		f           func(x Dual) Dual
+		fv, df, d2f func(x float64) float64
+		points      []float64
+	}{
+		{
+			name: "F1: x + 1/x^2",
+			f: func(x Dual) Dual {
+				return x.Add(x.Mul(x).Inv())
+			},
+			fv:     pkg.F1,
+			df:     pkg.DF1,
+			d2f:    pkg.DDF1,
+			points: []float64{0.3, 1, 1.26, 2, 5},
+		},
+		{
+			name: "sin(x)·e^x + √x·ln x - x^1.5",
+			f: func(x Dual) Dual {
+				return Sin(x).Mul(Exp(x)).Add(Sqrt(x).Mul(Log(x))).Sub(Pow(x, 1.5))
+			},
+			fv: func(x float64) float64 {
+				return math.Sin(x)*math.Exp(x) + math.Sqrt(x)*math.Log(x) - math.Pow(x, 1.5)
+			},
+			df: func(x float64) float64 {
+				return (math.Sin(x)+math.Cos(x))*math.Exp(x) + math.Log(x)/(2*math.Sqrt(x)) + 1/math.Sqrt(x) - 1.5*math.Sqrt(x)
+			},
+			d2f: func(x float64) float64 {
+				return 2*math.Cos(x)*math.Exp(x) - math.Log(x)/(4*math.Pow(x, 1.5)) - 0.75/math.Sqrt(x)
+			},
+			points: []float64{0.5, 1, 2.5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fv, df, d2f := Derivatives(tt.f)
+			for _, x := range tt.points {
+				if got, want := fv(x), tt.fv(x); math.Abs(got-want) > 1e-12*max(1, math.Abs(want)) {
+					t.Errorf("f(%v) = %v, want %v", x, got, want)
+				}
+				if got, want := df(x), tt.df(x); math.Abs(got-want) > 1e-12*max(1, math.Abs(want)) {
+					t.Errorf("f'(%v) = %v, want %v", x, got, want)
+				}
+				if got, want := d2f(x), tt.d2f(x); math.Abs(got-want) > 1e-12*max(1, math.Abs(want)) {
+					t.Errorf("f''(%v) = %v, want %v", x, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGradHess2(t *testing.T) {
+	// F2(x, y) = x² + e^(x²+y²) + 4x + 3y
+	f := func(x, y Dual) Dual {
+		return x.Mul(x).Add(Exp(x.Mul(x).Add(y.Mul(y)))).Add(x.MulConst(4)).Add(y.MulConst(3))
+	}
+	fv, grad, hess := Func2(f), Grad2(f), Hess2(f)
+	for _, p := range [][2]float64{{0, 0}, {1, 1}, {-0.613225, -0.663293}, {0.5, -1.5}} {
+		x, y := p[0], p[1]
+		if got, want := fv(x, y), pkg.F2(x, y); math.Abs(got-want) > 1e-12*max(1, math.Abs(want)) {
+			t.Errorf("f(%v, %v) = %v, want %v", x, y, got, want)
+		}
+		gx, gy := grad(x, y)
+		wx, wy := pkg.GradF2(x, y)
+		if math.Abs(gx-wx) > 1e-12*max(1, math.Abs(wx)) || math.Abs(gy-wy) > 1e-12*max(1, math.Abs(wy)) {
+			t.Errorf("grad(%v, %v) = (%v, %v), want (%v, %v)", x, y, gx, gy, wx, wy)
+		}
+		hxx, hxy, hyx, hyy := hess(x, y)
+		wxx, wxy, wyx, wyy := pkg.HessF2(x, y)
+		got := []float64{hxx, hxy, hyx, hyy}
+		for i, want := range []float64{wxx, wxy, wyx, wyy} {
+			if math.Abs(got[i]-want) > 1e-12*max(1, math.Abs(want)) {
+				t.Errorf("hess(%v, %v) = %v, want (%v, %v, %v, %v)", x, y, got, wxx, wxy, wyx, wyy)
+				break
+			}
+		}
+	}
+}
+
+func TestGradHessN(t *testing.T) {
+	// на F2 GradN и HessN совпадают с Grad2 и Hess2
+	f2 := func(x, y Dual) Dual {
+		return x.Mul(x).Add(Exp(x.Mul(x).Add(y.Mul(y)))).Add(x.MulConst(4)).Add(y.MulConst(3))
+	}
+	fn := func(x []Dual) Dual { return f2(x[0], x[1]) }
+	fv, grad, hess := FuncN(fn), GradN(fn), HessN(fn)
+	g, h := make([]float64, 2), make([]float64, 4)
+	for _, x := range [][]float64{{0, 0}, {1, 1}, {-0.613225, -0.663293}, {0.5, -1.5}} {
+		if got, want := fv(x), pkg.F2(x[0], x[1]); math.Abs(got-want) > 1e-12*max(1, math.Abs(want)) {
+			t.Errorf("f(%v) = %v, want %v", x, got, want)
+		}
+		grad(x, g)
+		wx, wy := Grad2(f2)(x[0], x[1])
+		if g[0] != wx || g[1] != wy {
+			t.Errorf("grad(%v) = %v, want [%v %v]", x, g, wx, wy)
+		}
+		hess(x, h)
+		wxx, wxy, wyx, wyy := Hess2(f2)(x[0], x[1])
+		if want := []float64{wxx, wxy, wyx, wyy}; !slices.Equal(h, want) {
+			t.Errorf("hess(%v) = %v, want %v", x, h, want)
+		}
+	}
+}
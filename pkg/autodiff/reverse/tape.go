@@ -0,0 +1,247 @@
+// Package reverse реализует обратный режим автоматического дифференцирования.
+//
+// Вычисление целевой функции записывается на ленту (Tape): каждая операция
+// сохраняет ссылки на аргументы и локальные частные производные. Затем один
+// обратный проход по ленте даёт весь градиент, независимо от числа переменных —
+// в отличие от прямого режима (autodiff.Dual), которому нужен проход на каждую переменную.
+package reverse
+
+import "math"
+
+// node — запись ленты: до двух аргументов a, b (индексы узлов, -1 — нет аргумента)
+// и частные производные результата по ним.
+type node struct {
+	a, b   int
+	da, db float64
+}
+
+// Tape — лента вычислений. Нулевое значение готово к использованию.
+// Ленту можно переиспользовать между вычислениями через Reset.
+type Tape struct {
+	nodes []node
+	vars  []int
+	adj   []float64
+}
+
+// Value — значение, вычисленное на ленте. Константы (Const) лентой не отслеживаются.
+type Value struct {
+	V    float64
+	tape *Tape
+	id   int
+}
+
+// NewTape создаёт пустую ленту.
+func NewTape() *Tape {
+	return &Tape{}
+}
+
+// Reset очищает ленту, сохраняя выделенную память.
+func (t *Tape) Reset() {
+	t.nodes = t.nodes[:0]
+	t.vars = t.vars[:0]
+}
+
+// Var добавляет на ленту независимую переменную со значением x.
+// Производные в Backward возвращаются в порядке вызовов Var.
+func (t *Tape) Var(x float64) Value {
+	t.vars = append(t.vars, len(t.nodes))
+	return t.push(x, node{a: -1, b: -1})
+}
+
+// Vars добавляет на ленту независимые переменные со значениями xs.
+func (t *Tape) Vars(xs []float64) []Value {
+	vs := make([]Value, len(xs))
+	for i, x := range xs {
+		vs[i] = t.Var(x)
+	}
+	return vs
+}
+
+// Backward выполняет обратный проход от результата y и записывает в grad
+// частные производные y по переменным ленты (len(grad) должна быть не меньше их числа).
+func (t *Tape) Backward(y Value, grad []float64) {
+	for i := range t.vars {
+		grad[i] = 0
+	}
+	if y.tape != t {
+		return
+	}
+
+	if cap(t.adj) <= y.id {
+		t.adj = make([]float64, y.id+1)
+	}
+	t.adj = t.adj[:y.id+1]
+	clear(t.adj)
+	t.adj[y.id] = 1
+	for i := y.id; i >= 0; i-- {
+		w := t.adj[i]
+		if w == 0 {
+			continue
+		}
+		n := t.nodes[i]
+		if n.a >= 0 {
+			t.adj[n.a] += n.da * w
+		}
+		if n.b >= 0 {
+			t.adj[n.b] += n.db * w
+		}
+	}
+	for i, id := range t.vars {
+		if id <= y.id {
+			grad[i] = t.adj[id]
+		}
+	}
+}
+
+func (t *Tape) push(v float64, n node) Value {
+	t.nodes = append(t.nodes, n)
+	return Value{V: v, tape: t, id: len(t.nodes) - 1}
+}
+
+// Const возвращает константу c.
+func Const(c float64) Value {
+	return Value{V: c, id: -1}
+}
+
+// unary записывает на ленту g(x) с производной dg = g'(x.V).
+func (x Value) unary(g, dg float64) Value {
+	if x.tape == nil {
+		return Const(g)
+	}
+	return x.tape.push(g, node{a: x.id, b: -1, da: dg})
+}
+
+// binary записывает на ленту g(x, y) с частными производными dx и dy.
+func (x Value) binary(y Value, g, dx, dy float64) Value {
+	t := x.tape
+	if t == nil {
+		t = y.tape
+	}
+	if t == nil {
+		return Const(g)
+	}
+	return t.push(g, node{a: x.id, b: y.id, da: dx, db: dy})
+}
+
+// Add возвращает x + y.
+func (x Value) Add(y Value) Value {
+	return x.binary(y, x.V+y.V, 1, 1)
+}
+
+// Sub возвращает x - y.
+func (x Value) Sub(y Value) Value {
+	return x.binary(y, x.V-y.V, 1, -1)
+}
+
+// Mul возвращает x · y.
+func (x Value) Mul(y Value) Value {
+	return x.binary(y, x.V*y.V, y.V, x.V)
+}
+
+// Div возвращает x / y.
+func (x Value) Div(y Value) Value {
+	return x.binary(y, x.V/y.V, 1/y.V, -x.V/(y.V*y.V))
+}
+
+// Neg возвращает -x.
+func (x Value) Neg() Value {
+	return x.unary(-x.V, -1)
+}
+
+// AddConst возвращает x + c.
+func (x Value) AddConst(c float64) Value {
+	return x.unary(x.V+c, 1)
+}
+
+// MulConst возвращает c · x.
+func (x Value) MulConst(c float64) Value {
+	return x.unary(c*x.V, c)
+}
+
+// Exp возвращает e^x.
+func Exp(x Value) Value {
+	e := math.Exp(x.V)
+	return x.unary(e, e)
+}
+
+// Log возвращает натуральный логарифм x.
+func Log(x Value) Value {
+	return x.unary(math.Log(x.V), 1/x.V)
+}
+
+// Sin возвращает sin x.
+func Sin(x Value) Value {
+	return x.unary(math.Sin(x.V), math.Cos(x.V))
+}
+
+// Cos возвращает cos x.
+func Cos(x Value) Value {
+	return x.unary(math.Cos(x.V), -math.Sin(x.V))
+}
+
+// Sqrt возвращает √x.
+func Sqrt(x Value) Value {
+	r := math.Sqrt(x.V)
+	return x.unary(r, 0.5/r)
+}
+
+// Pow возвращает x^p для вещественной степени p.
+func Pow(x Value, p float64) Value {
+	return x.unary(math.Pow(x.V, p), p*math.Pow(x.V, p-1))
+}
+
+// Abs возвращает |x|; в нуле производная считается равной нулю.
+func Abs(x Value) Value {
+	switch {
+	case x.V > 0:
+		return x.unary(x.V, 1)
+	case x.V < 0:
+		return x.unary(-x.V, -1)
+	}
+	return x.unary(0, 0)
+}
+
+// Sum возвращает сумму xs.
+func Sum(xs ...Value) Value {
+	s := Const(0)
+	for _, x := range xs {
+		s = s.Add(x)
+	}
+	return s
+}
+
+// Gradient по функции многих переменных, записанной через Value, строит функцию,
+// которая возвращает f(x) и записывает градиент в grad за один обратный проход.
+// Лента переиспользуется между вызовами, поэтому результат не безопасен для конкурентного использования.
+func Gradient(f func(x []Value) Value) func(x, grad []float64) float64 {
+	t := NewTape()
+	return func(x, grad []float64) float64 {
+		t.Reset()
+		y := f(t.Vars(x))
+		t.Backward(y, grad)
+		return y.V
+	}
+}
+
+// GradN строит градиент функции многих переменных в виде, который ожидают методы
+// multidimensional с суффиксом N (QuasiNewtonN, ConjGradFRN и др.): значение f(x),
+// возвращаемое Gradient, отбрасывается.
+func GradN(f func(x []Value) Value) func(x, g []float64) {
+	grad := Gradient(f)
+	return func(x, g []float64) {
+		grad(x, g)
+	}
+}
+
+// Grad2 строит градиент функции двух переменных в виде, который ожидают методы
+// multidimensional (GradientDescentBacktracking, QuasiNewton, ConjGradFR и др.).
+func Grad2(f func(x, y Value) Value) func(x, y float64) (gx, gy float64) {
+	t := NewTape()
+	var grad [2]float64
+	return func(x, y float64) (gx, gy float64) {
+		t.Reset()
+		r := f(t.Var(x), t.Var(y))
+		t.Backward(r, grad[:])
+		return grad[0], grad[1]
+	}
+}
@@ -0,0 +1,109 @@
+package reverse
+
+import (
+	"math"
+	"testing"
+
+	"github.com/vshulcz/edu_optimization_methods/pkg"
+)
+
+func TestGrad2(t *testing.T) {
+	// F2(x, y) = x² + e^(x²+y²) + 4x + 3y
+	grad := Grad2(func(x, y Value) Value {
+		return Sum(x.Mul(x), Exp(x.Mul(x).Add(y.Mul(y))), x.MulConst(4), y.MulConst(3))
+	})
+	for _, p := range [][2]float64{{0, 0}, {1, 1}, {-0.613225, -0.663293}, {0.5, -1.5}} {
+		x, y := p[0], p[1]
+		gx, gy := grad(x, y)
+		wx, wy := pkg.GradF2(x, y)
+		if math.Abs(gx-wx) > 1e-12*max(1, math.Abs(wx)) || math.Abs(gy-wy) > 1e-12*max(1, math.Abs(wy)) {
+			t.Errorf("grad(%v, %v) = (%v, %v), want (%v, %v)", x, y, gx, gy, wx, wy)
+		}
+	}
+}
+
+func TestGradient(t *testing.T) {
+	// расширенная функция Розенброка от n = 200 переменных
+	// и функция, использующая все остальные операции
+	tests := []struct {
+		name string
+		f    func(x []Value) Value
+		fv   func(x []float64) float64
+		grad func(x, grad []float64)
+		x    []float64
+	}{
+		{
+			name: "Rosenbrock",
+			f: func(x []Value) Value {
+				s := Const(0)
+				for i := 0; i+1 < len(x); i++ {
+					a := x[i+1].Sub(x[i].Mul(x[i]))
+					b := Const(1).Sub(x[i])
+					s = s.Add(a.Mul(a).MulConst(100)).Add(b.Mul(b))
+				}
+				return s
+			},
+			fv: func(x []float64) float64 {
+				s := 0.0
+				for i := 0; i+1 < len(x); i++ {
+					s += 100*math.Pow(x[i+1]-x[i]*x[i], 2) + math.Pow(1-x[i], 2)
+				}
+				return s
+			},
+			grad: func(x, grad []float64) {
+				clear(grad)
+				for i := 0; i+1 < len(x); i++ {
+					a := x[i+1] - x[i]*x[i]
+					grad[i] += -400*a*x[i] - 2*(1-x[i])
+					grad[i+1] += 200 * a
+				}
+			},
+			x: func() []float64 {
+				x := make([]float64, 200)
+				for i := range x {
+					x[i] = math.Sin(float64(i))
+				}
+				return x
+			}(),
+		},
+		{
+			name: "x0/x1 + ln x0 · cos x1 - sin(√x0) + |x1|^1.5",
+			f: func(x []Value) Value {
+				return Sum(
+					x[0].Div(x[1]),
+					Log(x[0]).Mul(Cos(x[1])),
+					Sin(Sqrt(x[0])).Neg(),
+					Pow(Abs(x[1]), 1.5),
+				)
+			},
+			fv: func(x []float64) float64 {
+				return x[0]/x[1] + math.Log(x[0])*math.Cos(x[1]) - math.Sin(math.Sqrt(x[0])) + math.Pow(math.Abs(x[1]), 1.5)
+			},
+			grad: func(x, grad []float64) {
+				grad[0] = 1/x[1] + math.Cos(x[1])/x[0] - math.Cos(math.Sqrt(x[0]))/(2*math.Sqrt(x[0]))
+				grad[1] = -x[0]/(x[1]*x[1]) - math.Log(x[0])*math.Sin(x[1]) - 1.5*math.Sqrt(-x[1])
+			},
+			x: []float64{2, -0.7},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gradient := Gradient(tt.f)
+			got := make([]float64, len(tt.x))
+			want := make([]float64, len(tt.x))
+			// второй вызов проверяет переиспользование ленты
+			for range 2 {
+				fx := gradient(tt.x, got)
+				if w := tt.fv(tt.x); math.Abs(fx-w) > 1e-9*max(1, math.Abs(w)) {
+					t.Errorf("f(x) = %v, want %v", fx, w)
+				}
+				tt.grad(tt.x, want)
+				for i := range want {
+					if math.Abs(got[i]-want[i]) > 1e-9*max(1, math.Abs(want[i])) {
+						t.Errorf("grad[%d] = %v, want %v", i, got[i], want[i])
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package pkg
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrShortTrace возвращается EstimateConvergence, если в последовательности
+// меньше трёх убывающих положительных погрешностей.
+var ErrShortTrace = errors.New("too few iterates to estimate convergence")
+
+// noiseFloor — погрешности и шаги не больше noiseFloor·ε·масштаб считаются шумом округления
+// и отбрасываются вместе со всем хвостом последовательности.
+const noiseFloor = 64
+
+// Trace — последовательность приближений x_0, x_1, ... метода (каждое — вектор координат).
+// Методы, поддерживающие трассировку, принимают tr *pkg.Trace и сами добавляют в неё
+// каждое приближение; nil означает, что трасса не нужна:
+//
+//	var tr pkg.Trace
+//	res, err := highordered.NewtonSearch(pkg.F1, pkg.DF1, pkg.DDF1, x0, eps, highordered.DefaultMaxIter, &tr)
+//	order, rate, err := pkg.EstimateConvergence(tr.Steps())
+type Trace [][]float64
+
+// Add добавляет приближение x, если оно отличается от последнего добавленного.
+// Вызов на nil-трассе ничего не делает.
+func (t *Trace) Add(x ...float64) {
+	if t == nil {
+		return
+	}
+	if n := len(*t); n > 0 && equal((*t)[n-1], x) {
+		return
+	}
+	*t = append(*t, append([]float64(nil), x...))
+}
+
+// Errors возвращает погрешности e_k = ||x_k - xstar|| до первой, сравнимой с округлением.
+func (t Trace) Errors(xstar ...float64) []float64 {
+	floor := noiseFloor * epsilon * max(norm(xstar), 1)
+	errs := make([]float64, 0, len(t))
+	for _, x := range t {
+		e := dist(x, xstar)
+		if e <= floor {
+			break
+		}
+		errs = append(errs, e)
+	}
+	return errs
+}
+
+// Steps возвращает длины шагов s_k = ||x_{k+1} - x_k|| до первого, сравнимого с округлением.
+// При сверхлинейной сходимости s_k ≈ e_k, поэтому шаги заменяют неизвестные погрешности.
+func (t Trace) Steps() []float64 {
+	steps := make([]float64, 0, len(t))
+	for k := 1; k < len(t); k++ {
+		s := dist(t[k], t[k-1])
+		if s <= noiseFloor*epsilon*max(norm(t[k]), 1) {
+			break
+		}
+		steps = append(steps, s)
+	}
+	return steps
+}
+
+// EstimateConvergence оценивает эмпирический порядок p и константу C сходимости
+// e_{k+1} ≈ C·e_k^p по последним трём убывающим положительным погрешностям (или шагам) errs:
+//
+//	p = ln(e_{k+1} / e_k) / ln(e_k / e_{k-1}),  C = e_{k+1} / e_k^p.
+//
+// Для метода Ньютона ожидается p ≈ 2, для метода секущих p ≈ (1 + √5)/2 ≈ 1.618,
+// для линейно сходящихся методов p ≈ 1 и C < 1 — знаменатель прогрессии.
+//
+// Возвращает ErrShortTrace, если подходящей тройки нет.
+func EstimateConvergence(errs []float64) (order, rate float64, err error) {
+	for k := len(errs) - 2; k >= 1; k-- {
+		e0, e1, e2 := errs[k-1], errs[k], errs[k+1]
+		if e2 <= 0 || e2 >= e1 || e1 >= e0 {
+			continue
+		}
+		order = math.Log(e2/e1) / math.Log(e1/e0)
+		rate = e2 / math.Pow(e1, order)
+		return order, rate, nil
+	}
+	return 0, 0, ErrShortTrace
+}
+
+// epsilon — машинная точность float64.
+var epsilon = math.Nextafter(1, 2) - 1
+
+func equal(x, y []float64) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func norm(x []float64) float64 {
+	var s float64
+	for _, v := range x {
+		s += v * v
+	}
+	return math.Sqrt(s)
+}
+
+func dist(x, y []float64) float64 {
+	var s float64
+	for i := range x {
+		d := x[i] - y[i]
+		s += d * d
+	}
+	return math.Sqrt(s)
+}
@@ -0,0 +1,62 @@
+package pkg
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestEstimateConvergence(t *testing.T) {
+	// последовательность e_{k+1} = C·e_k^p
+	sequence := func(e0, order, rate float64, n int) []float64 {
+		errs := []float64{e0}
+		for len(errs) < n {
+			errs = append(errs, rate*math.Pow(errs[len(errs)-1], order))
+		}
+		return errs
+	}
+	tests := []struct {
+		name      string
+		errs      []float64
+		wantOrder float64
+		wantRate  float64
+		wantErr   error
+	}{
+		{name: "linear", errs: sequence(1, 1, 0.3, 10), wantOrder: 1, wantRate: 0.3},
+		{name: "superlinear", errs: sequence(0.5, (1+math.Sqrt(5))/2, 1.3, 5), wantOrder: (1 + math.Sqrt(5)) / 2, wantRate: 1.3},
+		{name: "quadratic", errs: sequence(0.1, 2, 0.5, 4), wantOrder: 2, wantRate: 0.5},
+		{name: "growing tail is skipped", errs: append(sequence(0.1, 2, 0.5, 4), 1e-10), wantOrder: 2, wantRate: 0.5},
+		{name: "too short", errs: []float64{1, 0.1}, wantErr: ErrShortTrace},
+		{name: "not decreasing", errs: []float64{1, 2, 3, 4}, wantErr: ErrShortTrace},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, rate, err := EstimateConvergence(tt.errs)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("EstimateConvergence() error = %v, want %v", err, tt.wantErr)
+			}
+			if math.Abs(order-tt.wantOrder) > 1e-6 || math.Abs(rate-tt.wantRate) > 1e-6 {
+				t.Errorf("EstimateConvergence() = %v, %v, want %v, %v", order, rate, tt.wantOrder, tt.wantRate)
+			}
+		})
+	}
+}
+
+func TestTrace(t *testing.T) {
+	var tr Trace
+	for _, p := range [][2]float64{{0, 0}, {0, 0}, {1, 1}, {1, 1 + 1e-17}, {0.5, 1}} {
+		tr.Add(p[0], p[1])
+	}
+	if len(tr) != 3 {
+		t.Fatalf("len(Trace) = %v, want 3 (repeated points are recorded once): %v", len(tr), tr)
+	}
+	if got, want := tr.Steps(), []float64{math.Sqrt2, 0.5}; len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Steps() = %v, want %v", got, want)
+	}
+	// погрешность последней точки сравнима с округлением: она и хвост отбрасываются
+	if got := tr.Errors(0.5, 1); len(got) != 2 {
+		t.Errorf("Errors() = %v, want 2 values", got)
+	}
+	var none *Trace
+	none.Add(1, 2) // не паникует
+}
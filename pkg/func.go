@@ -14,6 +14,10 @@ func DDF1(x float64) float64 {
 	return 6 / (x * x * x * x)
 }
 
+func D3F1(x float64) float64 {
+	return -24 / (x * x * x * x * x)
+}
+
 func F2(x, y float64) float64 {
 	return x*x + math.Exp(x*x+y*y) + 4*x + 3*y
 }
@@ -0,0 +1,158 @@
+package numdiff
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrMismatch возвращается Report.Validate, если заявленная производная расходится с численной.
+var ErrMismatch = errors.New("derivative mismatch")
+
+// ErrUnreliable возвращается Report.Validate, если численное значение недостаточно точно,
+// чтобы подтвердить или опровергнуть заявленную производную.
+var ErrUnreliable = errors.New("numeric derivative is unreliable")
+
+// Discrepancy — наибольшее расхождение заявленной производной с численной по одной компоненте.
+//
+// RelErr = |Claimed - Numeric| / max(|Claimed|, |Numeric|, 1): для малых значений производной
+// это абсолютная погрешность, для больших — относительная. RelEst = errEst / max(|Numeric|, 1) —
+// оценка погрешности Numeric, полученная экстраполяцией Ричардсона.
+//
+// Точки, где численное значение не конечно или его RelEst больше 1e-6 (например, разности
+// задевают особенность f), в сравнении не участвуют и перечисляются в Unreliable.
+type Discrepancy struct {
+	Component  string    // компонента: "f'", "f''", "gx", "gy", "hxx", "hxy", "hyx", "hyy"
+	At         []float64 // точка, в которой расхождение наибольшее
+	Claimed    float64   // значение заявленной производной
+	Numeric    float64   // значение, полученное экстраполяцией Ричардсона
+	RelErr     float64
+	RelEst     float64
+	Unreliable [][]float64 // точки, пропущенные из-за ненадёжного численного значения
+}
+
+func (d Discrepancy) String() string {
+	s := fmt.Sprintf("%s at %v: claimed %g, numeric %g ± %.3g, rel. error %.3g",
+		d.Component, d.At, d.Claimed, d.Numeric, d.RelEst, d.RelErr)
+	if len(d.Unreliable) > 0 {
+		s += fmt.Sprintf(" (unreliable at %v)", d.Unreliable)
+	}
+	return s
+}
+
+// Report — результат проверки: по одной записи Discrepancy на каждую компоненту.
+type Report []Discrepancy
+
+// Worst возвращает компоненту с наибольшим относительным расхождением.
+func (r Report) Worst() Discrepancy {
+	var worst Discrepancy
+	for i, d := range r {
+		if i == 0 || d.RelErr > worst.RelErr || math.IsNaN(d.RelErr) {
+			worst = d
+		}
+	}
+	return worst
+}
+
+// Validate возвращает ошибку, оборачивающую ErrMismatch, если расхождение
+// хотя бы по одной компоненте больше tol (или не является числом), а численное значение
+// достаточно точно (RelEst ≤ tol). Если расхождение больше tol, но и RelEst > tol,
+// или в компоненте не осталось ни одной надёжной точки, возвращается ErrUnreliable:
+// заявленная производная в этом случае не считается ошибочной.
+func (r Report) Validate(tol float64) error {
+	var mismatch, unreliable []Discrepancy
+	for _, d := range r {
+		switch {
+		case d.At == nil && len(d.Unreliable) > 0:
+			unreliable = append(unreliable, d)
+		case d.RelErr > tol || math.IsNaN(d.RelErr):
+			if d.RelEst > tol {
+				unreliable = append(unreliable, d)
+			} else {
+				mismatch = append(mismatch, d)
+			}
+		}
+	}
+	if len(mismatch) > 0 {
+		return fmt.Errorf("%w: %v", ErrMismatch, Report(mismatch).Worst())
+	}
+	if len(unreliable) > 0 {
+		return fmt.Errorf("%w: %v", ErrUnreliable, unreliable[0])
+	}
+	return nil
+}
+
+// Check1D сравнивает заявленные производные df и d2f функции f с численными
+// (экстраполяция Ричардсона) в точках points. Если d2f == nil, проверяется только df.
+//
+// Пример предварительной проверки перед NewtonSearch:
+//
+//	if err := numdiff.Check1D(pkg.F1, pkg.DF1, pkg.DDF1, []float64{0.5, 1, 2}).Validate(1e-6); err != nil {
+//		...
+//	}
+//
+// Возвращает Report с наибольшим расхождением для f' и f”; точки, где численное значение
+// ненадёжно (например, вблизи особенности f), пропускаются и перечисляются в Unreliable.
+func Check1D(f, df, d2f func(x float64) float64, points []float64) Report {
+	r := Report{{Component: "f'"}}
+	if d2f != nil {
+		r = append(r, Discrepancy{Component: "f''"})
+	}
+	for _, x := range points {
+		numeric, errEst := Richardson(f, x, 0)
+		r[0].update([]float64{x}, df(x), numeric, errEst)
+		if d2f != nil {
+			numeric, errEst = SecondRichardson(f, x, 0)
+			r[1].update([]float64{x}, d2f(x), numeric, errEst)
+		}
+	}
+	return r
+}
+
+// Check2D сравнивает заявленные градиент grad и матрицу Гессе hess функции двух переменных f
+// с численными (Grad2 и Hess2) в точках points. Если hess == nil, проверяется только градиент.
+//
+// Возвращает Report с наибольшим расхождением по каждой компоненте градиента и матрицы Гессе;
+// ненадёжные точки пропускаются, как в Check1D.
+func Check2D(
+	f func(x, y float64) float64,
+	grad func(x, y float64) (gx, gy float64),
+	hess func(x, y float64) (hxx, hxy, hyx, hyy float64),
+	points [][2]float64,
+) Report {
+	r := Report{{Component: "gx"}, {Component: "gy"}}
+	if hess != nil {
+		r = append(r, Discrepancy{Component: "hxx"}, Discrepancy{Component: "hxy"},
+			Discrepancy{Component: "hyx"}, Discrepancy{Component: "hyy"})
+	}
+	for _, p := range points {
+		at := []float64{p[0], p[1]}
+		gx, gy := grad(p[0], p[1])
+		nx, ny, ex, ey := grad2(f, p[0], p[1])
+		r[0].update(at, gx, nx, ex)
+		r[1].update(at, gy, ny, ey)
+		if hess != nil {
+			hxx, hxy, hyx, hyy := hess(p[0], p[1])
+			nxx, nxy, nyy, exx, exy, eyy := hess2(f, p[0], p[1])
+			r[2].update(at, hxx, nxx, exx)
+			r[3].update(at, hxy, nxy, exy)
+			r[4].update(at, hyx, nxy, exy)
+			r[5].update(at, hyy, nyy, eyy)
+		}
+	}
+	return r
+}
+
+// update запоминает точку at, если расхождение в ней больше уже найденного.
+// Точки с ненадёжным численным значением numeric ± errEst не сравниваются, а попадают в Unreliable.
+func (d *Discrepancy) update(at []float64, claimed, numeric, errEst float64) {
+	relEst := errEst / max(math.Abs(numeric), 1)
+	if !finite(numeric) || !(relEst <= richardsonTrust) {
+		d.Unreliable = append(d.Unreliable, at)
+		return
+	}
+	relErr := math.Abs(claimed-numeric) / max(math.Abs(claimed), math.Abs(numeric), 1)
+	if d.At == nil || relErr > d.RelErr || math.IsNaN(relErr) && !math.IsNaN(d.RelErr) {
+		d.At, d.Claimed, d.Numeric, d.RelErr, d.RelEst = at, claimed, numeric, relErr, relEst
+	}
+}
@@ -0,0 +1,82 @@
+package numdiff
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/vshulcz/edu_optimization_methods/pkg"
+)
+
+func TestCheck(t *testing.T) {
+	points1 := []float64{0.5, 1, 2, 4}
+	points2 := [][2]float64{{0, 0}, {1, 1}, {-0.6, -0.7}, {0.5, -1.5}}
+
+	// правильные производные
+	if err := Check1D(pkg.F1, pkg.DF1, pkg.DDF1, points1).Validate(1e-7); err != nil {
+		t.Errorf("Check1D(F1): unexpected error %v", err)
+	}
+	if err := Check2D(pkg.F2, pkg.GradF2, pkg.HessF2, points2).Validate(1e-6); err != nil {
+		t.Errorf("Check2D(F2): unexpected error %v", err)
+	}
+
+	// опечатка во второй производной: 6/x^3 вместо 6/x^4
+	badDDF1 := func(x float64) float64 { return 6 / (x * x * x) }
+	r := Check1D(pkg.F1, pkg.DF1, badDDF1, points1)
+	if err := r.Validate(1e-7); !errors.Is(err, ErrMismatch) {
+		t.Errorf("Check1D(F1, bad f''): got %v, expected ErrMismatch", err)
+	}
+	if w := r.Worst(); w.Component != "f''" || w.At[0] != 0.5 {
+		t.Errorf("Check1D(F1, bad f''): worst = %v, expected f'' at [0.5]", w)
+	}
+	if r[0].RelErr > 1e-7 {
+		t.Errorf("Check1D(F1, bad f''): f' flagged: %v", r[0])
+	}
+
+	// опечатка в gy: 2y·e^(x²+y²) + 2 вместо + 3
+	badGrad := func(x, y float64) (gx, gy float64) {
+		gx, gy = pkg.GradF2(x, y)
+		return gx, gy - 1
+	}
+	r = Check2D(pkg.F2, badGrad, nil, points2)
+	if len(r) != 2 {
+		t.Fatalf("Check2D without hess: got %d components, expected 2", len(r))
+	}
+	w := r.Worst()
+	if w.Component != "gy" || math.Abs(w.Claimed-w.Numeric-(-1)) > 1e-6 {
+		t.Errorf("Check2D(F2, bad gy): worst = %v, expected gy off by -1", w)
+	}
+	if r[0].RelErr > 1e-6 {
+		t.Errorf("Check2D(F2, bad gy): gx flagged: %v", r[0])
+	}
+}
+
+func TestCheckNearSingularity(t *testing.T) {
+	// правильные производные F1 вблизи полюса в нуле
+	if err := Check1D(pkg.F1, pkg.DF1, pkg.DDF1, []float64{0.001, 0.05, 0.08, 0.1}).Validate(1e-7); err != nil {
+		t.Errorf("Check1D(F1) near 0: unexpected error %v", err)
+	}
+
+	// f(x) = 1/x: в самом полюсе численного значения нет, точка пропускается
+	inv := func(x float64) float64 { return 1 / x }
+	dinv := func(x float64) float64 { return -1 / (x * x) }
+	d2inv := func(x float64) float64 { return 2 / (x * x * x) }
+	r := Check1D(inv, dinv, d2inv, []float64{0, 1})
+	if err := r.Validate(1e-7); err != nil {
+		t.Errorf("Check1D(1/x) at {0, 1}: unexpected error %v", err)
+	}
+	for _, d := range r {
+		if len(d.Unreliable) != 1 || d.Unreliable[0][0] != 0 {
+			t.Errorf("Check1D(1/x) at {0, 1}: %s unreliable at %v, expected [[0]]", d.Component, d.Unreliable)
+		}
+	}
+	if err := Check1D(inv, dinv, d2inv, []float64{0}).Validate(1e-7); !errors.Is(err, ErrUnreliable) {
+		t.Errorf("Check1D(1/x) at {0}: got %v, expected ErrUnreliable", err)
+	}
+
+	// ошибка в f' по-прежнему находится рядом с ненадёжной точкой
+	badDinv := func(x float64) float64 { return 1 / (x * x) }
+	if err := Check1D(inv, badDinv, nil, []float64{0, 1}).Validate(1e-7); !errors.Is(err, ErrMismatch) {
+		t.Errorf("Check1D(1/x, bad f') at {0, 1}: got %v, expected ErrMismatch", err)
+	}
+}
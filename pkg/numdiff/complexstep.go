@@ -0,0 +1,77 @@
+package numdiff
+
+import "math"
+
+// complexStep — относительный шаг комплексного дифференцирования по умолчанию.
+// Вычитания в формуле нет, поэтому шаг можно брать сколь угодно малым.
+const complexStep = 1e-20
+
+// ComplexStep вычисляет производную комплексным шагом: f'(x) ≈ Im f(x + ih) / h.
+//
+// f должна быть аналитическим продолжением вещественной функции: записана через
+// арифметику complex128 и функции math/cmplx (Exp, Log, Sin, Sqrt, Pow и др.),
+// без Abs, сравнений и взятия Re/Im. При h ≤ 0 шаг равен 1e-20·max(|x|, 1).
+//
+// Особенности:
+// - Погрешность усечения O(h²), а вычитания близких чисел нет: результат точен до машинной точности.
+// - Годится там, где конечные разности теряют все знаки (например, DF1 вблизи нуля).
+// - Требует одного вызова f, но в комплексной арифметике.
+func ComplexStep(f func(z complex128) complex128, x, h float64) float64 {
+	if h <= 0 {
+		h = complexStep * max(math.Abs(x), 1)
+	}
+	return imag(f(complex(x, h))) / h
+}
+
+// ComplexDerivative строит f' комплексным шагом в виде,
+// который ожидают методы highordered (TangentSearch, NewtonSearch и др.).
+func ComplexDerivative(f func(z complex128) complex128) func(x float64) float64 {
+	return func(x float64) float64 {
+		return ComplexStep(f, x, 0)
+	}
+}
+
+// ComplexGrad2 строит градиент функции двух переменных комплексным шагом
+// в виде, который ожидают методы multidimensional (SteepestGradientDescent и др.).
+func ComplexGrad2(f func(x, y complex128) complex128) func(x, y float64) (gx, gy float64) {
+	return func(x, y float64) (gx, gy float64) {
+		gx = ComplexStep(func(z complex128) complex128 { return f(z, complex(y, 0)) }, x, 0)
+		gy = ComplexStep(func(z complex128) complex128 { return f(complex(x, 0), z) }, y, 0)
+		return
+	}
+}
+
+// ComplexGradient строит градиент функции многих переменных комплексным шагом:
+// возвращаемая функция записывает градиент в grad и возвращает f(x).
+// Требует len(x) + 1 вызовов f.
+func ComplexGradient(f func(z []complex128) complex128) func(x, grad []float64) float64 {
+	return func(x, grad []float64) float64 {
+		z := complexGradient(f, x, grad)
+		return real(f(z))
+	}
+}
+
+// ComplexGradN строит градиент функции многих переменных комплексным шагом в виде,
+// который ожидают методы multidimensional с суффиксом N (QuasiNewtonN, ConjGradFRN и др.).
+// В отличие от ComplexGradient, f(x) не вычисляется: требуется ровно len(x) вызовов f.
+func ComplexGradN(f func(z []complex128) complex128) func(x, g []float64) {
+	return func(x, g []float64) {
+		complexGradient(f, x, g)
+	}
+}
+
+// complexGradient записывает в grad градиент f в точке x (len(x) вызовов f)
+// и возвращает x в комплексном виде.
+func complexGradient(f func(z []complex128) complex128, x, grad []float64) []complex128 {
+	z := make([]complex128, len(x))
+	for i, xi := range x {
+		z[i] = complex(xi, 0)
+	}
+	for i, xi := range x {
+		h := complexStep * max(math.Abs(xi), 1)
+		z[i] = complex(xi, h)
+		grad[i] = imag(f(z)) / h
+		z[i] = complex(xi, 0)
+	}
+	return z
+}
@@ -0,0 +1,56 @@
+package numdiff
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/vshulcz/edu_optimization_methods/pkg"
+)
+
+func TestComplexStep(t *testing.T) {
+	// F1(x) = x + 1/x²: вблизи нуля |F1| ~ 1/x², и конечные разности теряют точность
+	f1 := func(z complex128) complex128 { return z + 1/(z*z) }
+	df := ComplexDerivative(f1)
+	for _, x := range []float64{1e-4, 1e-3, 0.01, 0.3, 1.26, 100} {
+		want := pkg.DF1(x)
+		got := df(x)
+		if math.Abs(got-want) > 1e-15*max(1, math.Abs(want)) {
+			t.Errorf("df(%v) = %v, want %v", x, got, want)
+		}
+		if x < 0.01 {
+			if central, _ := Central(pkg.F1, x, 0); math.Abs(central-want) <= math.Abs(got-want) {
+				t.Errorf("x = %v: central difference %v is not worse than complex step %v", x, central, got)
+			}
+		}
+	}
+
+	// F2(x, y) = x² + e^(x²+y²) + 4x + 3y
+	f2 := func(x, y complex128) complex128 { return x*x + cmplx.Exp(x*x+y*y) + 4*x + 3*y }
+	grad := ComplexGrad2(f2)
+	gradN := ComplexGradient(func(z []complex128) complex128 { return f2(z[0], z[1]) })
+	g := make([]float64, 2)
+	for _, p := range [][2]float64{{0, 0}, {1, 1}, {-0.613225, -0.663293}, {0.5, -1.5}} {
+		x, y := p[0], p[1]
+		wx, wy := pkg.GradF2(x, y)
+		gx, gy := grad(x, y)
+		if math.Abs(gx-wx) > 1e-15*max(1, math.Abs(wx)) || math.Abs(gy-wy) > 1e-15*max(1, math.Abs(wy)) {
+			t.Errorf("grad(%v, %v) = (%v, %v), want (%v, %v)", x, y, gx, gy, wx, wy)
+		}
+		fv := gradN(p[:], g)
+		if fv != pkg.F2(x, y) || g[0] != gx || g[1] != gy {
+			t.Errorf("ComplexGradient(%v, %v) = %v, %v, want %v, [%v %v]", x, y, fv, g, pkg.F2(x, y), gx, gy)
+		}
+	}
+
+	// ComplexGradN вычисляет f ровно по одному разу на координату
+	var evals int
+	gradOnly := ComplexGradN(func(z []complex128) complex128 {
+		evals++
+		return f2(z[0], z[1])
+	})
+	gradOnly([]float64{0.5, -1.5}, g)
+	if wx, wy := ComplexGrad2(f2)(0.5, -1.5); evals != 2 || g[0] != wx || g[1] != wy {
+		t.Errorf("ComplexGradN(0.5, -1.5) = %v with %v evaluations, want [%v %v] with 2", g, evals, wx, wy)
+	}
+}
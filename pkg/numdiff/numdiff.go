@@ -0,0 +1,250 @@
+// Package numdiff реализует численное дифференцирование: конечные разности
+// и экстраполяцию Ричардсона (метод Риддерса) с оценкой погрешности,
+// а для аналитических функций — дифференцирование комплексным шагом.
+//
+// Пакет позволяет применять методы, которым нужны производные
+// (TangentSearch, NewtonSearch, SteepestGradientDescent, NewtonModified и др.),
+// когда аналитических производных нет.
+package numdiff
+
+import "math"
+
+// Параметры экстраполяции Ричардсона: шаг на каждой ступени делится на richardsonShrink,
+// таблица содержит не более richardsonSize ступеней, а процесс прекращается,
+// если погрешность выросла более чем в richardsonSafe раз.
+const (
+	richardsonShrink = 1.4
+	richardsonSize   = 10
+	richardsonSafe   = 2.0
+)
+
+// Параметры выбора начального шага Ричардсона: шаг равен richardsonRel·|x| (richardsonRel при x = 0)
+// и делится на richardsonRetryShrink, пока таблица не станет конечной и согласованной
+// (errEst ≤ richardsonTrust·max(|d|, 1)), но не более richardsonRetries раз.
+const (
+	richardsonRel         = 0.1
+	richardsonRetries     = 8
+	richardsonRetryShrink = 10.0
+	richardsonTrust       = 1e-6
+)
+
+// epsilon — машинная точность float64.
+var epsilon = math.Nextafter(1, 2) - 1
+
+// step возвращает шаг h (при h ≤ 0 — rel·max(|x|, 1)), скорректированный так,
+// чтобы x + h точно представлялось в арифметике с плавающей точкой.
+func step(x, h, rel float64) float64 {
+	if h <= 0 {
+		h = rel * max(math.Abs(x), 1)
+	}
+	tmp := x + h
+	return tmp - x
+}
+
+// Forward вычисляет производную правой разностью f'(x) ≈ (f(x + h) - f(x)) / h.
+//
+// При h ≤ 0 шаг выбирается автоматически: h = √ε·max(|x|, 1), где ε — машинная точность,
+// что уравновешивает погрешность усечения O(h) и погрешность округления O(ε/h).
+//
+// Возвращает оценку производной d и оценку погрешности errEst: разность с результатом для шага h/2
+// (погрешность усечения) плюс погрешность округления 2ε|f(x)|/h.
+func Forward(f func(x float64) float64, x, h float64) (d, errEst float64) {
+	h = step(x, h, math.Sqrt(epsilon))
+	fx := f(x)
+	d = (f(x+h) - fx) / h
+	d2 := (f(x+h/2) - fx) / (h / 2)
+	return d, math.Abs(d-d2) + 2*epsilon*math.Abs(fx)/h
+}
+
+// Central вычисляет производную центральной разностью f'(x) ≈ (f(x + h) - f(x - h)) / (2h).
+//
+// При h ≤ 0 шаг выбирается автоматически: h = ε^(1/3)·max(|x|, 1),
+// так как погрешность усечения здесь O(h²).
+//
+// Возвращает оценку производной d и оценку погрешности errEst: разность с результатом для шага h/2
+// плюс погрешность округления ε|f(x)|/h.
+func Central(f func(x float64) float64, x, h float64) (d, errEst float64) {
+	h = step(x, h, math.Cbrt(epsilon))
+	d = centralDiff(f, x, h)
+	d2 := centralDiff(f, x, h/2)
+	return d, math.Abs(d-d2) + epsilon*math.Abs(f(x))/h
+}
+
+// Richardson вычисляет производную экстраполяцией Ричардсона центральных разностей (метод Риддерса).
+//
+// Алгоритм:
+//   - Начиная с шага h (при h ≤ 0 — 0.1·|x|, а при x = 0 — 0.1), строятся центральные разности
+//     D(h), D(h/c), D(h/c²), ...
+//   - Каждый новый столбец таблицы исключает очередной член разложения погрешности:
+//     D_j = (c^(2j)·D_{j-1}(h/c) - D_{j-1}(h)) / (c^(2j) - 1).
+//   - Выбирается элемент таблицы с наименьшей оценкой погрешности; процесс прекращается,
+//     когда погрешность начинает расти из-за округления.
+//   - Если результат не конечен или оценка погрешности больше 1e-6·max(|d|, 1) (разности задевают
+//     особенность f или область, где f ведёт себя иначе), начальный шаг уменьшается в 10 раз
+//     и таблица строится заново.
+//
+// Особенности:
+// - Точность близка к машинной для гладких функций, но требует до 20 вызовов f на каждую таблицу.
+// - Шаг пропорционален |x|: разности не пересекают особенность в нуле, но при малых |x| растёт округление (видно по errEst).
+//
+// Возвращает оценку производной d и оценку погрешности errEst лучшей из построенных таблиц.
+// Если конечного результата получить не удалось, d = NaN, errEst = +Inf.
+func Richardson(f func(x float64) float64, x, h float64) (d, errEst float64) {
+	return adaptive(x, h, func(h float64) float64 {
+		return centralDiff(f, x, h)
+	})
+}
+
+// SecondCentral вычисляет вторую производную f”(x) ≈ (f(x + h) - 2f(x) + f(x - h)) / h².
+//
+// При h ≤ 0 шаг выбирается автоматически: h = ε^(1/4)·max(|x|, 1).
+//
+// Возвращает оценку второй производной d и оценку погрешности errEst: разность с результатом
+// для шага h/2 плюс погрешность округления 4ε|f(x)|/h².
+func SecondCentral(f func(x float64) float64, x, h float64) (d, errEst float64) {
+	h = step(x, h, math.Sqrt(math.Sqrt(epsilon)))
+	fx := f(x)
+	d = secondDiff(f, x, fx, h)
+	d2 := secondDiff(f, x, fx, h/2)
+	return d, math.Abs(d-d2) + 4*epsilon*math.Abs(fx)/(h*h)
+}
+
+// SecondRichardson вычисляет вторую производную экстраполяцией Ричардсона
+// вторых центральных разностей. Параметры, выбор шага и результат — как у Richardson.
+func SecondRichardson(f func(x float64) float64, x, h float64) (d, errEst float64) {
+	fx := f(x)
+	return adaptive(x, h, func(h float64) float64 {
+		return secondDiff(f, x, fx, h)
+	})
+}
+
+func centralDiff(f func(x float64) float64, x, h float64) float64 {
+	return (f(x+h) - f(x-h)) / (2 * h)
+}
+
+func secondDiff(f func(x float64) float64, x, fx, h float64) float64 {
+	return (f(x+h) - 2*fx + f(x-h)) / (h * h)
+}
+
+// adaptive строит таблицу Ричардсона для D с начальным шагом h (при h ≤ 0 — richardsonRel·|x|)
+// и уменьшает шаг, пока результат не станет конечным и согласованным.
+// Возвращает результат с наименьшей оценкой погрешности.
+func adaptive(x, h float64, D func(h float64) float64) (d, errEst float64) {
+	if h <= 0 {
+		h = richardsonRel * math.Abs(x)
+		if h == 0 {
+			h = richardsonRel
+		}
+	}
+	d, errEst = math.NaN(), math.Inf(1)
+	for range richardsonRetries {
+		h = step(x, h, richardsonRel)
+		if h == 0 {
+			break
+		}
+		di, ei := extrapolate(h, D)
+		if finite(di) && ei < errEst {
+			d, errEst = di, ei
+		}
+		if errEst <= richardsonTrust*max(math.Abs(d), 1) {
+			break
+		}
+		h /= richardsonRetryShrink
+	}
+	return
+}
+
+// finite сообщает, является ли x конечным числом.
+func finite(x float64) bool {
+	return !math.IsNaN(x) && !math.IsInf(x, 0)
+}
+
+// extrapolate строит таблицу Ричардсона для разностной формулы D(h)
+// с погрешностью вида c1·h² + c2·h⁴ + ... и возвращает лучший элемент и его погрешность.
+func extrapolate(h float64, D func(h float64) float64) (d, errEst float64) {
+	var table [richardsonSize][richardsonSize]float64
+	table[0][0] = D(h)
+	d, errEst = table[0][0], math.Inf(1)
+	for i := 1; i < richardsonSize; i++ {
+		h /= richardsonShrink
+		table[0][i] = D(h)
+		fac := richardsonShrink * richardsonShrink
+		for j := 1; j <= i; j++ {
+			table[j][i] = (table[j-1][i]*fac - table[j-1][i-1]) / (fac - 1)
+			fac *= richardsonShrink * richardsonShrink
+			e := max(math.Abs(table[j][i]-table[j-1][i]), math.Abs(table[j][i]-table[j-1][i-1]))
+			if e <= errEst {
+				d, errEst = table[j][i], e
+			}
+		}
+		if math.Abs(table[i][i]-table[i-1][i-1]) >= richardsonSafe*errEst {
+			break
+		}
+	}
+	return
+}
+
+// Derivatives строит f' и f” функции f экстраполяцией Ричардсона
+// в виде, который ожидают методы highordered (TangentSearch, NewtonSearch и др.).
+// Оценка погрешности отбрасывается; если она нужна, используйте DerivativesErr.
+func Derivatives(f func(x float64) float64) (df, d2f func(x float64) float64) {
+	dfErr, d2fErr := DerivativesErr(f)
+	df = func(x float64) float64 {
+		d, _ := dfErr(x)
+		return d
+	}
+	d2f = func(x float64) float64 {
+		d, _ := d2fErr(x)
+		return d
+	}
+	return
+}
+
+// DerivativesErr строит f' и f” функции f экстраполяцией Ричардсона вместе с оценкой погрешности:
+// по ней можно отличить надёжное значение от полученного вблизи особенности f.
+func DerivativesErr(f func(x float64) float64) (df, d2f func(x float64) (d, errEst float64)) {
+	df = func(x float64) (d, errEst float64) {
+		return Richardson(f, x, 0)
+	}
+	d2f = func(x float64) (d, errEst float64) {
+		return SecondRichardson(f, x, 0)
+	}
+	return
+}
+
+// Grad2 строит градиент функции двух переменных экстраполяцией Ричардсона
+// в виде, который ожидают методы multidimensional (SteepestGradientDescent и др.).
+func Grad2(f func(x, y float64) float64) func(x, y float64) (gx, gy float64) {
+	return func(x, y float64) (gx, gy float64) {
+		gx, gy, _, _ = grad2(f, x, y)
+		return
+	}
+}
+
+// Hess2 строит матрицу Гессе функции двух переменных экстраполяцией Ричардсона
+// в виде, который ожидает multidimensional.NewtonModified.
+// Смешанная производная вычисляется по формуле
+// (f(x+h, y+h) - f(x+h, y-h) - f(x-h, y+h) + f(x-h, y-h)) / (4h²).
+func Hess2(f func(x, y float64) float64) func(x, y float64) (hxx, hxy, hyx, hyy float64) {
+	return func(x, y float64) (hxx, hxy, hyx, hyy float64) {
+		hxx, hxy, hyy, _, _, _ = hess2(f, x, y)
+		return hxx, hxy, hxy, hyy
+	}
+}
+
+// grad2 вычисляет градиент, как Grad2, вместе с оценками погрешности компонент.
+func grad2(f func(x, y float64) float64, x, y float64) (gx, gy, ex, ey float64) {
+	gx, ex = Richardson(func(t float64) float64 { return f(t, y) }, x, 0)
+	gy, ey = Richardson(func(t float64) float64 { return f(x, t) }, y, 0)
+	return
+}
+
+// hess2 вычисляет матрицу Гессе, как Hess2, вместе с оценками погрешности элементов.
+func hess2(f func(x, y float64) float64, x, y float64) (hxx, hxy, hyy, exx, exy, eyy float64) {
+	hxx, exx = SecondRichardson(func(t float64) float64 { return f(t, y) }, x, 0)
+	hyy, eyy = SecondRichardson(func(t float64) float64 { return f(x, t) }, y, 0)
+	hxy, exy = adaptive(max(math.Abs(x), math.Abs(y)), 0, func(h float64) float64 {
+		return (f(x+h, y+h) - f(x+h, y-h) - f(x-h, y+h) + f(x-h, y-h)) / (4 * h * h)
+	})
+	return
+}
@@ -0,0 +1,93 @@
+package numdiff
+
+import (
+	"math"
+	"testing"
+
+	"github.com/vshulcz/edu_optimization_methods/pkg"
+)
+
+func TestDerivative(t *testing.T) {
+	methods := []struct {
+		name   string
+		diff   func(f func(x float64) float64, x, h float64) (d, errEst float64)
+		second bool
+		tol    float64
+	}{
+		{name: "Forward", diff: Forward, tol: 1e-7},
+		{name: "Central", diff: Central, tol: 1e-9},
+		{name: "Richardson", diff: Richardson, tol: 1e-12},
+		{name: "SecondCentral", diff: SecondCentral, second: true, tol: 1e-6},
+		{name: "SecondRichardson", diff: SecondRichardson, second: true, tol: 1e-9},
+	}
+	for _, m := range methods {
+		t.Run(m.name, func(t *testing.T) {
+			for _, x := range []float64{0.3, 1, 1.26, 5, 100} {
+				want := pkg.DF1(x)
+				if m.second {
+					want = pkg.DDF1(x)
+				}
+				d, errEst := m.diff(pkg.F1, x, 0)
+				if math.Abs(d-want) > m.tol*max(1, math.Abs(want)) {
+					t.Errorf("x = %v: got %v, want %v", x, d, want)
+				}
+				// оценка погрешности должна быть того же порядка, что и истинная погрешность
+				if math.Abs(d-want) > 10*errEst+1e-14*max(1, math.Abs(want)) {
+					t.Errorf("x = %v: error %v is not covered by estimate %v", x, math.Abs(d-want), errEst)
+				}
+			}
+		})
+	}
+}
+
+func TestRichardsonNearSingularity(t *testing.T) {
+	// F1 = x + 1/x² имеет полюс в нуле: разности с шагом 0.1·max(|x|, 1) его пересекают
+	for _, x := range []float64{-0.05, 0.001, 0.05, 0.08, 0.1} {
+		d, errEst := Richardson(pkg.F1, x, 0)
+		if want := pkg.DF1(x); math.Abs(d-want) > 1e-9*math.Abs(want) || errEst > 1e-6*math.Abs(want) {
+			t.Errorf("Richardson(F1, %v) = %v ± %v, want %v", x, d, errEst, want)
+		}
+		d, errEst = SecondRichardson(pkg.F1, x, 0)
+		if want := pkg.DDF1(x); math.Abs(d-want) > 1e-9*math.Abs(want) || errEst > 1e-6*math.Abs(want) {
+			t.Errorf("SecondRichardson(F1, %v) = %v ± %v, want %v", x, d, errEst, want)
+		}
+	}
+	// в самом полюсе производной нет: оценка погрешности должна это показать
+	if d, errEst := Richardson(func(x float64) float64 { return 1 / x }, 0, 0); errEst <= richardsonTrust*max(math.Abs(d), 1) {
+		t.Errorf("Richardson(1/x, 0) = %v ± %v, want an unreliable estimate", d, errEst)
+	}
+}
+
+func TestDerivatives(t *testing.T) {
+	df, d2f := Derivatives(pkg.F1)
+	grad, hess := Grad2(pkg.F2), Hess2(pkg.F2)
+	dfErr, _ := DerivativesErr(pkg.F1)
+	for _, x := range []float64{0.5, 1.26, 3} {
+		if got, want := df(x), pkg.DF1(x); math.Abs(got-want) > 1e-10 {
+			t.Errorf("df(%v) = %v, want %v", x, got, want)
+		}
+		if got, errEst := dfErr(x); got != df(x) || math.Abs(got-pkg.DF1(x)) > 10*errEst+1e-14 {
+			t.Errorf("dfErr(%v) = %v ± %v, want %v", x, got, errEst, df(x))
+		}
+		if got, want := d2f(x), pkg.DDF1(x); math.Abs(got-want) > 1e-7 {
+			t.Errorf("d2f(%v) = %v, want %v", x, got, want)
+		}
+	}
+	for _, p := range [][2]float64{{0, 0}, {1, 1}, {-0.613225, -0.663293}, {0.5, -1.5}} {
+		x, y := p[0], p[1]
+		gx, gy := grad(x, y)
+		wx, wy := pkg.GradF2(x, y)
+		if math.Abs(gx-wx) > 1e-9*max(1, math.Abs(wx)) || math.Abs(gy-wy) > 1e-9*max(1, math.Abs(wy)) {
+			t.Errorf("grad(%v, %v) = (%v, %v), want (%v, %v)", x, y, gx, gy, wx, wy)
+		}
+		hxx, hxy, hyx, hyy := hess(x, y)
+		wxx, wxy, wyx, wyy := pkg.HessF2(x, y)
+		got := []float64{hxx, hxy, hyx, hyy}
+		for i, want := range []float64{wxx, wxy, wyx, wyy} {
+			if math.Abs(got[i]-want) > 1e-6*max(1, math.Abs(want)) {
+				t.Errorf("hess(%v, %v) = %v, want (%v, %v, %v, %v)", x, y, got, wxx, wxy, wyx, wyy)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package pkg
+
+// StopReason — причина остановки одномерного метода.
+type StopReason int
+
+const (
+	// StopInterval — длина отрезка локализации (или шаг сетки) стала не больше требуемой точности.
+	StopInterval StopReason = iota
+	// StopGradient — достигнута стационарная точка: |f'(x)| ≤ eps.
+	StopGradient
+	// StopBoundary — минимум на отрезке достигается на его границе.
+	StopBoundary
+	// StopZeroCurvature — вторая производная обратилась в нуль, шаг Ньютона невозможен.
+	StopZeroCurvature
+	// StopZeroDenominator — разность производных в секущей обратилась в нуль.
+	StopZeroDenominator
+	// StopGap — разность между найденным значением и нижней границей минимума не больше eps.
+	StopGap
+	// StopDegenerate — отрезок выродился до машинной точности.
+	StopDegenerate
+	// StopBudget — исчерпан бюджет вычислений функции.
+	StopBudget
+	// StopMaxIter — исчерпан лимит итераций.
+	StopMaxIter
+	// StopDiverged — очередное приближение не является конечным числом.
+	StopDiverged
+	// StopStagnation — приближение перестало изменяться до выполнения условия остановы.
+	StopStagnation
+	// StopNotBracketed — отрезок не локализует минимум.
+	StopNotBracketed
+)
+
+func (r StopReason) String() string {
+	switch r {
+	case StopInterval:
+		return "interval"
+	case StopGradient:
+		return "gradient"
+	case StopBoundary:
+		return "boundary"
+	case StopZeroCurvature:
+		return "zero curvature"
+	case StopZeroDenominator:
+		return "zero denominator"
+	case StopGap:
+		return "gap"
+	case StopDegenerate:
+		return "degenerate"
+	case StopBudget:
+		return "budget"
+	case StopMaxIter:
+		return "max iterations"
+	case StopDiverged:
+		return "diverged"
+	case StopStagnation:
+		return "stagnation"
+	case StopNotBracketed:
+		return "not bracketed"
+	}
+	return "unknown"
+}
+
+// Result1D — общий результат одномерных методов минимизации.
+//
+// Iters — число итераций самого метода (сокращений отрезка, шагов Ньютона и т.п.;
+// у пассивного поиска — число узлов сетки), а FEvals, DFEvals, D2FEvals и D3FEvals — число
+// вычислений f, f', f” и f”' соответственно.
+//
+// [AFinal, BFinal] — финальный отрезок локализации минимума: он содержит Xmin и лежит
+// в исходном отрезке. Для методов без отрезка локализации (Ньютона, секущих) —
+// отрезок между двумя последними приближениями.
+type Result1D struct {
+	Xmin, Fmin     float64
+	AFinal, BFinal float64
+
+	Iters                               int
+	FEvals, DFEvals, D2FEvals, D3FEvals int
+
+	Reason StopReason
+}
+
+// LineMinimizer — одномерный метод минимизации на отрезке [a, b] с точностью eps.
+// Используется многомерными методами для поиска шага вдоль направления.
+type LineMinimizer interface {
+	Minimize(f func(x float64) float64, a, b, eps float64) Result1D
+}
+
+// LineMinimizerFunc позволяет использовать функцию с сигнатурой GoldenSectionSearch как LineMinimizer.
+type LineMinimizerFunc func(f func(x float64) float64, a, b, eps float64) Result1D
+
+// Minimize вызывает m(f, a, b, eps).
+func (m LineMinimizerFunc) Minimize(f func(x float64) float64, a, b, eps float64) Result1D {
+	return m(f, a, b, eps)
+}
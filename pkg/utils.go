@@ -1,6 +1,31 @@
 package pkg
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrNoBracket возвращается, если не удалось найти тройку точек, локализующую минимум.
+var ErrNoBracket = errors.New("no bracket found")
+
+// ErrSingular возвращается SolveGauss, если матрица системы вырождена (ведущий элемент меньше 1e-12).
+var ErrSingular = errors.New("matrix is singular")
+
+// ErrFlat возвращается Bracket, если phi не убывает ни в одну сторону от x0, но и не возрастает
+// хотя бы в одну из них: в масштабе шага h функция постоянна с точностью до округления.
+// Оборачивает ErrNoBracket.
+var ErrFlat = fmt.Errorf("%w: function is flat", ErrNoBracket)
+
+// Параметры подбора отрезка по умолчанию для одномерной минимизации по шагу α.
+// Шаг растёт в отношении золотого сечения, как в методе Свенна у Press et al.; при этих значениях
+// методы multidimensional тратят не больше вызовов f, чем с прежним BracketMinimum.
+const (
+	BracketStep = 0.25
+	BracketGrow = 1.618033988749895
+
+	maxBracketIter = 100
+)
 
 // BracketMinimum подбирает начальный отрезок [a, b],
 // содержащий минимум унимодальной функции phi(alpha).
@@ -19,6 +44,9 @@ import "errors"
 // - Имеется ограничение на b (1e6), чтобы избежать бесконечного цикла.
 //
 // Возвращает: границы интервала [a, b], содержащего предполагаемый минимум.
+//
+// Deprecated: используйте Bracket — он ищет минимум в обе стороны от любой точки
+// и сообщает об ошибке, если локализовать минимум не удалось.
 func BracketMinimum(phi func(float64) float64) (a, b float64) {
 	a = 0
 	b = 1
@@ -33,6 +61,79 @@ func BracketMinimum(phi func(float64) float64) (a, b float64) {
 	return a, b
 }
 
+// Bracket подбирает тройку точек a < c < b, локализующую минимум функции phi,
+// то есть такую, что phi(c) < phi(a) и phi(c) < phi(b) (метод Свенна).
+//
+// Алгоритм:
+//   - Вычисляются phi(x0) и phi(x0 + h). Если значение уменьшилось, поиск идёт вправо,
+//     иначе вычисляется phi(x0 - h) и, если оно меньше phi(x0), поиск идёт влево.
+//   - Если phi(x0 - h) > phi(x0) < phi(x0 + h), тройка найдена сразу.
+//   - Иначе шаг в выбранном направлении увеличивается в grow раз (x_{k+1} = x_k + grow^k·h),
+//     пока функция убывает. Как только phi(x_{k+1}) > phi(x_k), тройка (x_{k-1}, x_k, x_{k+1})
+//     локализует минимум.
+//
+// Параметры:
+// - phi: функция одной переменной;
+// - x0: начальная точка;
+// - h > 0: начальный шаг;
+// - grow > 1: коэффициент увеличения шага.
+//
+// Особенности:
+//   - В отличие от BracketMinimum не предполагает, что минимум лежит правее начальной точки.
+//   - Если phi(x0 ± h) ≥ phi(x0) и хотя бы одно из значений равно phi(x0), возвращается ErrFlat:
+//     x0 неотличима от стационарной точки.
+//   - Если за maxBracketIter расширений функция не начала возрастать (не ограничена снизу
+//     или постоянна), либо получено нечисловое значение, возвращается ErrNoBracket.
+//
+// Возвращает: точки a < c < b и ошибку, если локализовать минимум не удалось.
+func Bracket(phi func(float64) float64, x0, h, grow float64) (a, c, b float64, err error) {
+	if h <= 0 || grow <= 1 {
+		return 0, 0, 0, errors.New("bracket step must be positive and growth factor greater than 1")
+	}
+
+	prev, fprev := x0, phi(x0)
+	cur, fcur := x0+h, phi(x0+h)
+	step := h
+	if fcur >= fprev {
+		left, fleft := x0-h, phi(x0-h)
+		if fleft >= fprev {
+			if fcur > fprev && fleft > fprev {
+				return left, x0, cur, nil
+			}
+			return 0, 0, 0, ErrFlat
+		}
+		cur, fcur = left, fleft
+		step = -h
+	}
+
+	for range maxBracketIter {
+		step *= grow
+		next := cur + step
+		if math.IsInf(next, 0) {
+			break
+		}
+		fnext := phi(next)
+		if math.IsNaN(fnext) || math.IsInf(fnext, -1) {
+			break
+		}
+		if fnext > fcur {
+			if fcur < fprev {
+				if step < 0 {
+					return next, cur, prev, nil
+				}
+				return prev, cur, next, nil
+			}
+			break
+		}
+		// на плато (fnext == fcur) левая граница не сдвигается, чтобы phi(a) > phi(c) оставалось строгим
+		if fnext < fcur {
+			prev, fprev = cur, fcur
+		}
+		cur, fcur = next, fnext
+	}
+	return 0, 0, 0, ErrNoBracket
+}
+
 func SolveGauss(A []float64, b []float64, n int) ([]float64, error) {
 	M := make([]float64, n*(n+1))
 	for i := 0; i < n; i++ {
@@ -49,7 +150,7 @@ func SolveGauss(A []float64, b []float64, n int) ([]float64, error) {
 			}
 		}
 		if abs(M[pivot*(n+1)+i]) < eps {
-			return nil, errors.New("matrix is singular")
+			return nil, ErrSingular
 		}
 		if pivot != i {
 			for k := i; k < n+1; k++ {
@@ -0,0 +1,77 @@
+package pkg
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestBracket(t *testing.T) {
+	tests := []struct {
+		name    string
+		phi     func(x float64) float64
+		x0      float64
+		wantMin float64
+		wantErr error
+	}{
+		{
+			name:    "minimum to the right: (x-5)^2",
+			phi:     func(x float64) float64 { return (x - 5) * (x - 5) },
+			x0:      0,
+			wantMin: 5,
+		},
+		{
+			name:    "minimum to the left: (x+3)^2",
+			phi:     func(x float64) float64 { return (x + 3) * (x + 3) },
+			x0:      0,
+			wantMin: -3,
+		},
+		{
+			name:    "start next to the minimum: x^2",
+			phi:     func(x float64) float64 { return x * x },
+			x0:      0.01,
+			wantMin: 0,
+		},
+		{
+			name:    "unbounded below: -x",
+			phi:     func(x float64) float64 { return -x },
+			x0:      0,
+			wantErr: ErrNoBracket,
+		},
+		{
+			name:    "flat bottom: max(|x-1|-0.4, 0)",
+			phi:     func(x float64) float64 { return max(math.Abs(x-1)-0.4, 0) },
+			x0:      0,
+			wantMin: 1,
+		},
+		{
+			name:    "constant",
+			phi:     func(x float64) float64 { return 1 },
+			x0:      0,
+			wantErr: ErrFlat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, c, b, err := Bracket(tt.phi, tt.x0, BracketStep, BracketGrow)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Bracket() err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Bracket() unexpected err = %v", err)
+			}
+			if !(a < c && c < b) {
+				t.Errorf("Bracket() = (%v, %v, %v), want a < c < b", a, c, b)
+			}
+			if !(tt.phi(c) < tt.phi(a) && tt.phi(c) < tt.phi(b)) {
+				t.Errorf("Bracket() = (%v, %v, %v), want phi(c) < phi(a), phi(b)", a, c, b)
+			}
+			if tt.wantMin < a || tt.wantMin > b || math.IsInf(b-a, 0) {
+				t.Errorf("Bracket() = [%v, %v], want it to contain %v", a, b, tt.wantMin)
+			}
+		})
+	}
+}